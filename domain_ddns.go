@@ -0,0 +1,269 @@
+package linodego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+)
+
+// DDNSUpdateAction is the kind of change made by UpdateDDNSRecords
+type DDNSUpdateAction string
+
+// DDNSUpdateAction constants are the outcomes UpdateDDNSRecords can report for a record
+const (
+	DDNSUnchanged DDNSUpdateAction = "unchanged"
+	DDNSUpdated   DDNSUpdateAction = "updated"
+	DDNSCreated   DDNSUpdateAction = "created"
+)
+
+// Defaults used when the corresponding DDNSUpdaterOptions are not set
+const (
+	defaultDDNSInterval   = 5 * time.Minute
+	defaultDDNSMinBackoff = 10 * time.Second
+	defaultDDNSMaxBackoff = 10 * time.Minute
+)
+
+// DDNSAddresses are the current public addresses of a host. Either address may be empty,
+// in which case the record of that family is left untouched.
+type DDNSAddresses struct {
+	IPv4 string
+	IPv6 string
+}
+
+// DDNSAddressDetector returns the current public addresses of the host
+type DDNSAddressDetector func(ctx context.Context) (DDNSAddresses, error)
+
+// DDNSUpdate is the outcome of UpdateDDNSRecords for a single record
+type DDNSUpdate struct {
+	Action   DDNSUpdateAction
+	Type     DomainRecordType
+	RecordID int
+	Address  string
+
+	// Previous is the address the record pointed to before it was updated
+	Previous string
+}
+
+func (u DDNSUpdate) String() string {
+	if u.Action == DDNSUpdated {
+		return fmt.Sprintf("%s %s %s -> %s", u.Action, u.Type, u.Previous, u.Address)
+	}
+
+	return fmt.Sprintf("%s %s %s", u.Action, u.Type, u.Address)
+}
+
+// DDNSUpdaterOptions configure RunDDNSUpdater
+type DDNSUpdaterOptions struct {
+	// DomainID and Name identify the records to keep up to date; an empty Name is the apex of the Domain
+	DomainID int
+	Name     string
+
+	// TTL of created records; 0 uses the Domain's default TTL
+	TTL int
+
+	// Detector returns the current public addresses of the host
+	Detector DDNSAddressDetector
+
+	// Interval between checks; defaults to 5 minutes
+	Interval time.Duration
+
+	// MinBackoff and MaxBackoff bound the delay before retrying after an error. The delay
+	// doubles with each consecutive error; they default to 10 seconds and 10 minutes.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// OnUpdate is called with the outcome of every check that changed a record
+	OnUpdate func([]DDNSUpdate)
+
+	// OnError is called with every error before backing off
+	OnError func(error)
+}
+
+// UpdateDDNSRecords points the A and AAAA records with the provided name in the Domain with the
+// provided ID at the provided addresses. A record that already points at the address is left
+// alone, an existing record is updated and a missing record is created with the provided TTL.
+// When several records of a family share the name, each one that doesn't point at the address
+// is updated so that resolvers never receive a stale address; no record is ever deleted.
+func (c *Client) UpdateDDNSRecords(
+	ctx context.Context,
+	domainID int,
+	name string,
+	addresses DDNSAddresses,
+	ttl int,
+) ([]DDNSUpdate, error) {
+	var updates []DDNSUpdate
+
+	for _, family := range []struct {
+		recordType DomainRecordType
+		address    string
+	}{{RecordTypeA, addresses.IPv4}, {RecordTypeAAAA, addresses.IPv6}} {
+		if family.address == "" {
+			continue
+		}
+
+		familyUpdates, err := c.updateDDNSRecord(ctx, domainID, name, family.recordType, family.address, ttl)
+		updates = append(updates, familyUpdates...)
+
+		if err != nil {
+			return updates, err
+		}
+	}
+
+	return updates, nil
+}
+
+func (c *Client) updateDDNSRecord(
+	ctx context.Context,
+	domainID int,
+	name string,
+	recordType DomainRecordType,
+	address string,
+	ttl int,
+) ([]DDNSUpdate, error) {
+	addr, err := netip.ParseAddr(address)
+	if err != nil || addr.Is4() != (recordType == RecordTypeA) {
+		return nil, fmt.Errorf("invalid address %q for %s record", address, recordType)
+	}
+
+	f := Filter{}
+	f.AddField(Eq, "name", name)
+	f.AddField(Eq, "type", recordType)
+
+	filter, err := f.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := c.ListDomainRecords(ctx, domainID, &ListOptions{Filter: string(filter)})
+	if err != nil {
+		return nil, err
+	}
+
+	target := addr.String()
+
+	if len(records) == 0 {
+		record, err := c.CreateDomainRecord(ctx, domainID, DomainRecordCreateOptions{
+			Type:   recordType,
+			Name:   name,
+			Target: target,
+			TTLSec: ttl,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []DDNSUpdate{{Action: DDNSCreated, Type: recordType, RecordID: record.ID, Address: target}}, nil
+	}
+
+	updates := make([]DDNSUpdate, 0, len(records))
+
+	for _, record := range records {
+		update := DDNSUpdate{Action: DDNSUnchanged, Type: recordType, RecordID: record.ID, Address: target}
+
+		if !domainRecordTargetEqual(recordType, record.Target, target) {
+			if _, err := c.UpdateDomainRecord(ctx, domainID, record.ID, DomainRecordUpdateOptions{Target: target}); err != nil {
+				return updates, err
+			}
+
+			update.Action = DDNSUpdated
+			update.Previous = record.Target
+		}
+
+		updates = append(updates, update)
+	}
+
+	return updates, nil
+}
+
+// RunDDNSUpdater keeps the A and AAAA records described by the options pointed at the addresses
+// returned by the detector until the context is cancelled. Every check compares the records'
+// current targets with the detected addresses, so records edited elsewhere are corrected, and
+// records are only written when they differ. Errors are retried with exponential backoff. It
+// returns the context's error once the context is done.
+func (c *Client) RunDDNSUpdater(ctx context.Context, opts DDNSUpdaterOptions) error {
+	if opts.Detector == nil {
+		return errors.New("a DDNS address detector is required")
+	}
+
+	if opts.Interval == 0 {
+		opts.Interval = defaultDDNSInterval
+	}
+
+	if opts.MinBackoff == 0 {
+		opts.MinBackoff = defaultDDNSMinBackoff
+	}
+
+	if opts.MaxBackoff == 0 {
+		opts.MaxBackoff = defaultDDNSMaxBackoff
+	}
+
+	failures := 0
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		addresses, err := opts.Detector(ctx)
+		if err == nil {
+			var updates []DDNSUpdate
+
+			updates, err = c.UpdateDDNSRecords(ctx, opts.DomainID, opts.Name, addresses, opts.TTL)
+			if err == nil && opts.OnUpdate != nil && ddnsRecordsChanged(updates) {
+				opts.OnUpdate(updates)
+			}
+		}
+
+		if err == nil {
+			failures = 0
+
+			timer.Reset(opts.Interval)
+
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if opts.OnError != nil {
+			opts.OnError(err)
+		}
+
+		failures++
+
+		timer.Reset(ddnsBackoff(opts.MinBackoff, opts.MaxBackoff, failures))
+	}
+}
+
+func ddnsRecordsChanged(updates []DDNSUpdate) bool {
+	for _, update := range updates {
+		if update.Action != DDNSUnchanged {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ddnsBackoff returns the delay before the next attempt after the provided number of consecutive failures
+func ddnsBackoff(minBackoff, maxBackoff time.Duration, failures int) time.Duration {
+	delay := minBackoff
+
+	for i := 1; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+
+	return delay
+}
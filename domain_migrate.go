@@ -0,0 +1,103 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+)
+
+// DomainMigrationOptions configure how MigrateDomain copies a Domain
+type DomainMigrationOptions struct {
+	// Domain is the name of the copy; the name of the source Domain is used when empty
+	Domain string
+
+	// SOAEmails maps SOA email addresses of the source Domain to the address used by the copy.
+	// Addresses that are not mapped are copied unchanged.
+	SOAEmails map[string]string
+
+	// Addresses maps A and AAAA record targets of the source Domain to the targets used by the
+	// copy, such as the IPs of the replacement Linodes in the destination account. Addresses
+	// that are not mapped are copied unchanged.
+	Addresses map[string]string
+}
+
+// MigrateDomain copies the Domain with the provided ID and all of its records from the source
+// client to the destination client, which may use different accounts. The SOA email and the
+// targets of A and AAAA records are remapped with the provided options. When copying a record
+// fails, the new Domain and the records copied so far are returned with the error.
+func MigrateDomain(
+	ctx context.Context,
+	source, destination *Client,
+	domainID int,
+	opts DomainMigrationOptions,
+) (*Domain, []DomainRecord, error) {
+	domain, err := source.GetDomain(ctx, domainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, err := source.ListDomainRecords(ctx, domainID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	createOpts := domain.GetCreateOptions()
+
+	if opts.Domain != "" {
+		createOpts.Domain = opts.Domain
+	}
+
+	if email, ok := opts.SOAEmails[createOpts.SOAEmail]; ok {
+		createOpts.SOAEmail = email
+	}
+
+	// Domains being edited or with errors are recreated as active
+	if createOpts.Status != DomainStatusDisabled {
+		createOpts.Status = DomainStatusActive
+	}
+
+	created, err := destination.CreateDomain(ctx, createOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	copied := make([]DomainRecord, 0, len(records))
+
+	for _, record := range records {
+		recordOpts := record.GetCreateOptions()
+
+		if record.Type == RecordTypeA || record.Type == RecordTypeAAAA {
+			recordOpts.Target = mapDomainRecordAddress(opts.Addresses, record.Target)
+		}
+
+		newRecord, err := destination.CreateDomainRecord(ctx, created.ID, recordOpts)
+		if err != nil {
+			return created, copied, fmt.Errorf("failed to copy %s record %q: %w", record.Type, domainRecordDisplayName(record.Name), err)
+		}
+
+		copied = append(copied, *newRecord)
+	}
+
+	return created, copied, nil
+}
+
+// mapDomainRecordAddress returns the mapped target of the address, comparing addresses
+// in their canonical form when there is no exact match
+func mapDomainRecordAddress(addresses map[string]string, target string) string {
+	if mapped, ok := addresses[target]; ok {
+		return mapped
+	}
+
+	addr, err := netip.ParseAddr(target)
+	if err != nil {
+		return target
+	}
+
+	for from, to := range addresses {
+		if fromAddr, err := netip.ParseAddr(from); err == nil && fromAddr == addr {
+			return to
+		}
+	}
+
+	return target
+}
@@ -79,6 +79,29 @@ func (d DomainRecord) GetUpdateOptions() (du DomainRecordUpdateOptions) {
 	return
 }
 
+// GetCreateOptions converts a DomainRecord to DomainRecordCreateOptions for use in CreateDomainRecord.
+// Priority, Weight and Port are only set for the record types that use them.
+func (d DomainRecord) GetCreateOptions() (dc DomainRecordCreateOptions) {
+	dc.Type = d.Type
+	dc.Name = d.Name
+	dc.Target = d.Target
+	dc.Service = copyString(d.Service)
+	dc.Protocol = copyString(d.Protocol)
+	dc.TTLSec = d.TTLSec
+	dc.Tag = copyString(d.Tag)
+
+	switch d.Type {
+	case RecordTypeMX:
+		dc.Priority = copyInt(&d.Priority)
+	case RecordTypeSRV:
+		dc.Priority = copyInt(&d.Priority)
+		dc.Weight = copyInt(&d.Weight)
+		dc.Port = copyInt(&d.Port)
+	}
+
+	return
+}
+
 // ListDomainRecords lists DomainRecords
 func (c *Client) ListDomainRecords(ctx context.Context, domainID int, opts *ListOptions) ([]DomainRecord, error) {
 	response, err := getPaginatedResults[DomainRecord](ctx, c, formatAPIPath("domains/%d/records", domainID), opts)
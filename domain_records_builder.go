@@ -0,0 +1,298 @@
+package linodego
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DomainRecordMaxTXTChunk is the maximum length in bytes of a single TXT character string
+const DomainRecordMaxTXTChunk = 255
+
+// CAA record tags accepted by the Linode API
+const (
+	CAATagIssue     = "issue"
+	CAATagIssueWild = "issuewild"
+	CAATagIODEF     = "iodef"
+)
+
+var (
+	domainRecordLabelRegex = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9_])?$`)
+	srvServiceRegex        = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,13}[A-Za-z0-9])?$`)
+	srvProtocols           = []string{"tcp", "udp", "xmpp", "tls", "smtp"}
+)
+
+// NewARecord returns the options to create an A record pointing the name at an IPv4 address.
+// An empty name is the apex of the Domain and a TTL of 0 uses the Domain's default TTL.
+func NewARecord(name, ipv4 string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{Type: RecordTypeA, Name: name, Target: ipv4, TTLSec: ttl})
+}
+
+// NewAAAARecord returns the options to create an AAAA record pointing the name at an IPv6 address
+func NewAAAARecord(name, ipv6 string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{Type: RecordTypeAAAA, Name: name, Target: ipv6, TTLSec: ttl})
+}
+
+// NewCNAMERecord returns the options to create a CNAME record aliasing the name to the target hostname
+func NewCNAMERecord(name, target string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{Type: RecordTypeCNAME, Name: name, Target: target, TTLSec: ttl})
+}
+
+// NewNSRecord returns the options to create an NS record delegating the name to the target nameserver
+func NewNSRecord(name, target string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{Type: RecordTypeNS, Name: name, Target: target, TTLSec: ttl})
+}
+
+// NewPTRRecord returns the options to create a PTR record pointing the name at the target hostname
+func NewPTRRecord(name, target string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{Type: RecordTypePTR, Name: name, Target: target, TTLSec: ttl})
+}
+
+// NewMXRecord returns the options to create an MX record routing mail for the name to the
+// target mail server with the provided priority (0-255)
+func NewMXRecord(name, target string, priority, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{
+		Type: RecordTypeMX, Name: name, Target: target, Priority: Pointer(priority), TTLSec: ttl,
+	})
+}
+
+// NewTXTRecord returns the options to create a TXT record. Values longer than 255 bytes,
+// such as DKIM keys, are split into quoted character strings of at most 255 bytes, which is
+// also how ParseZoneFile stores them.
+func NewTXTRecord(name, value string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{
+		Type: RecordTypeTXT, Name: name, Target: formatTXTTarget(value), TTLSec: ttl,
+	})
+}
+
+// NewSRVRecord returns the options to create an SRV record for the service and protocol, such as
+// "sip" and "tcp", pointing at the target host and port. The name is the subdomain the record
+// belongs to, or empty for the Domain itself; the API prefixes it with _service._protocol.
+func NewSRVRecord(service, protocol, name, target string, priority, weight, port, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{
+		Type:     RecordTypeSRV,
+		Name:     name,
+		Target:   target,
+		Service:  Pointer(strings.TrimPrefix(service, "_")),
+		Protocol: Pointer(strings.ToLower(strings.TrimPrefix(protocol, "_"))),
+		Priority: Pointer(priority),
+		Weight:   Pointer(weight),
+		Port:     Pointer(port),
+		TTLSec:   ttl,
+	})
+}
+
+// NewCAARecord returns the options to create a CAA record. The tag is one of CAATagIssue,
+// CAATagIssueWild or CAATagIODEF; the value is a certificate authority domain for the issue
+// tags and a mailto: or http(s): URL for iodef.
+func NewCAARecord(name, tag, value string, ttl int) (DomainRecordCreateOptions, error) {
+	return newDomainRecord(DomainRecordCreateOptions{
+		Type: RecordTypeCAA, Name: name, Target: value, Tag: Pointer(strings.ToLower(tag)), TTLSec: ttl,
+	})
+}
+
+// GetUpdateOptions converts DomainRecordCreateOptions to DomainRecordUpdateOptions for use in UpdateDomainRecord
+func (d DomainRecordCreateOptions) GetUpdateOptions() (du DomainRecordUpdateOptions) {
+	du.Type = d.Type
+	du.Name = d.Name
+	du.Target = d.Target
+	du.Priority = copyInt(d.Priority)
+	du.Weight = copyInt(d.Weight)
+	du.Port = copyInt(d.Port)
+	du.Service = copyString(d.Service)
+	du.Protocol = copyString(d.Protocol)
+	du.TTLSec = d.TTLSec
+	du.Tag = copyString(d.Tag)
+
+	return
+}
+
+// ValidateDomainRecord checks that the options carry the fields required by their record
+// type and that the name, target and TTL are acceptable to the Linode API. All problems
+// found are returned joined into a single error.
+//
+//nolint:gocognit
+func ValidateDomainRecord(opts DomainRecordCreateOptions) error {
+	var errs []error
+
+	addErr := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s record: "+format, append([]any{opts.Type}, args...)...))
+	}
+
+	// SRV names returned by the API include the _service._protocol labels
+	if opts.Name != "" && !(opts.Type == RecordTypeSRV && strings.HasPrefix(opts.Name, "_")) &&
+		!validDomainRecordName(opts.Name) {
+		addErr("invalid name %q", opts.Name)
+	}
+
+	if err := validateDomainRecordTTL(opts.TTLSec); err != nil {
+		addErr("%s", err)
+	}
+
+	if opts.Target == "" {
+		addErr("target is required")
+	}
+
+	switch opts.Type {
+	case RecordTypeA, RecordTypeAAAA:
+		if opts.Target == "" {
+			break
+		}
+
+		addr, err := netip.ParseAddr(opts.Target)
+
+		switch {
+		case err != nil || addr.Zone() != "":
+			addErr("invalid IP address %q", opts.Target)
+		case opts.Type == RecordTypeA && !addr.Is4():
+			addErr("target %q is not an IPv4 address", opts.Target)
+		case opts.Type == RecordTypeAAAA && (!addr.Is6() || addr.Is4In6()):
+			addErr("target %q is not an IPv6 address", opts.Target)
+		}
+	case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+		if opts.Target != "" && !validDomainRecordFQDN(opts.Target) {
+			addErr("invalid hostname %q", opts.Target)
+		}
+	case RecordTypeMX:
+		if opts.Target != "" && !validDomainRecordFQDN(opts.Target) {
+			addErr("invalid hostname %q", opts.Target)
+		}
+
+		if opts.Priority == nil {
+			addErr("priority is required")
+		} else if *opts.Priority < 0 || *opts.Priority > 255 {
+			addErr("priority %d must be between 0 and 255", *opts.Priority)
+		}
+	case RecordTypeTXT:
+		if err := validateTXTChunks(opts.Target); err != nil {
+			addErr("%s", err)
+		}
+	case RecordTypeSRV:
+		if opts.Target != "" && !validDomainRecordFQDN(opts.Target) {
+			addErr("invalid hostname %q", opts.Target)
+		}
+
+		if opts.Service == nil || !srvServiceRegex.MatchString(strings.TrimPrefix(*opts.Service, "_")) {
+			addErr("invalid service %q", domainRecordOptionalField(opts.Service))
+		}
+
+		if opts.Protocol == nil || !slices.Contains(srvProtocols, strings.ToLower(strings.TrimPrefix(*opts.Protocol, "_"))) {
+			addErr("protocol %q must be one of %s", domainRecordOptionalField(opts.Protocol), strings.Join(srvProtocols, ", "))
+		}
+
+		for _, field := range []struct {
+			name  string
+			value *int
+		}{{"priority", opts.Priority}, {"weight", opts.Weight}, {"port", opts.Port}} {
+			if field.value == nil {
+				addErr("%s is required", field.name)
+			} else if *field.value < 0 || *field.value > 65535 {
+				addErr("%s %d must be between 0 and 65535", field.name, *field.value)
+			}
+		}
+	case RecordTypeCAA:
+		if err := validateCAAValue(domainRecordOptionalField(opts.Tag), opts.Target); err != nil {
+			addErr("%s", err)
+		}
+	default:
+		addErr("unsupported record type")
+	}
+
+	return errors.Join(errs...)
+}
+
+func newDomainRecord(opts DomainRecordCreateOptions) (DomainRecordCreateOptions, error) {
+	if err := ValidateDomainRecord(opts); err != nil {
+		return DomainRecordCreateOptions{}, err
+	}
+
+	return opts, nil
+}
+
+func validateDomainRecordTTL(ttl int) error {
+	if ttl == 0 || slices.Contains(domainRecordTTLs, ttl) {
+		return nil
+	}
+
+	allowed := make([]string, len(domainRecordTTLs))
+	for i, v := range domainRecordTTLs {
+		allowed[i] = fmt.Sprint(v)
+	}
+
+	return fmt.Errorf("TTL %d must be 0 or one of %s", ttl, strings.Join(allowed, ", "))
+}
+
+// validDomainRecordName reports whether the name is a valid name relative to the Domain,
+// optionally starting with a wildcard label
+func validDomainRecordName(name string) bool {
+	if name == "*" {
+		return true
+	}
+
+	return validDomainRecordFQDN(strings.TrimPrefix(name, "*."))
+}
+
+// validDomainRecordFQDN reports whether the name is syntactically a valid hostname, with or
+// without a trailing dot
+func validDomainRecordFQDN(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+
+	if _, err := netip.ParseAddr(name); err == nil {
+		return false
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if !domainRecordLabelRegex.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateTXTChunks checks that a TXT value either fits in a single character string or is
+// made up of quoted character strings of at most 255 bytes each
+func validateTXTChunks(value string) error {
+	if len(value) <= DomainRecordMaxTXTChunk {
+		return nil
+	}
+
+	entries, err := tokenizeZoneFile(value)
+	if err != nil || len(entries) != 1 || !strings.HasPrefix(strings.TrimSpace(value), `"`) {
+		return fmt.Errorf("values longer than %d bytes must be split into quoted strings", DomainRecordMaxTXTChunk)
+	}
+
+	for _, chunk := range entries[0].tokens {
+		if len(chunk) > DomainRecordMaxTXTChunk {
+			return fmt.Errorf("quoted string of %d bytes exceeds %d bytes", len(chunk), DomainRecordMaxTXTChunk)
+		}
+	}
+
+	return nil
+}
+
+func validateCAAValue(tag, value string) error {
+	switch strings.ToLower(tag) {
+	case CAATagIssue, CAATagIssueWild:
+		// The value is an optional CA domain followed by optional parameters
+		domain := strings.TrimSpace(strings.SplitN(value, ";", 2)[0])
+		if domain != "" && !validDomainRecordFQDN(domain) {
+			return fmt.Errorf("invalid certificate authority %q", domain)
+		}
+	case CAATagIODEF:
+		if !strings.HasPrefix(value, "mailto:") && !strings.HasPrefix(value, "https://") &&
+			!strings.HasPrefix(value, "http://") {
+			return fmt.Errorf("iodef value %q must be a mailto:, http:// or https:// URL", value)
+		}
+	default:
+		return fmt.Errorf("tag %q must be one of %s, %s or %s", tag, CAATagIssue, CAATagIssueWild, CAATagIODEF)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package linodego
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDomainRecordConstructors(t *testing.T) {
+	if _, err := NewARecord("www", "192.0.2.1", 300); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if _, err := NewARecord("www", "2001:db8::1", 0); err == nil {
+		t.Error("expected an error for an IPv6 address in an A record")
+	}
+
+	if _, err := NewAAAARecord("www", "192.0.2.1", 0); err == nil {
+		t.Error("expected an error for an IPv4 address in an AAAA record")
+	}
+
+	if _, err := NewCNAMERecord("www", "-bad.example.com", 0); err == nil {
+		t.Error("expected an error for an invalid hostname")
+	}
+
+	if _, err := NewMXRecord("", "mail.example.com", 10, 1234); err == nil {
+		t.Error("expected an error for a TTL outside the allowed set")
+	}
+
+	srv, err := NewSRVRecord("_sip", "TCP", "voice", "sip.example.com", 10, 20, 5060, 3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *srv.Service != "sip" || *srv.Protocol != "tcp" || srv.Name != "voice" || *srv.Port != 5060 {
+		t.Errorf("unexpected SRV record: %+v", srv)
+	}
+
+	if _, err := NewSRVRecord("sip", "tcp", "bad name", "sip.example.com", 10, 20, 5060, 0); err == nil {
+		t.Error("expected an error for an invalid SRV name")
+	}
+
+	if _, err := NewSRVRecord("sip", "sctp", "", "sip.example.com", 10, 20, 70000, 0); err == nil ||
+		!strings.Contains(err.Error(), "protocol") || !strings.Contains(err.Error(), "port") {
+		t.Errorf("expected protocol and port errors, got %v", err)
+	}
+
+	if _, err := NewCAARecord("", "issue", "letsencrypt.org", 0); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if _, err := NewCAARecord("", "iodef", "security@example.com", 0); err == nil {
+		t.Error("expected an error for an iodef value without a scheme")
+	}
+
+	txt, err := NewTXTRecord("dkim._domainkey", strings.Repeat("k", 600), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(txt.Target, `"`) != 6 {
+		t.Errorf("expected 3 quoted chunks, got %s", txt.Target)
+	}
+
+	zone, err := ParseZoneFile(strings.NewReader(`dkim._domainkey IN TXT `+quoteZoneFileTXT(strings.Repeat("k", 600))+"\n"), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if zone.Records[0].Target != txt.Target {
+		t.Errorf("expected parsed and built TXT targets to match:\n%s\n%s", zone.Records[0].Target, txt.Target)
+	}
+
+	if short, _ := NewTXTRecord("", "v=spf1 -all", 0); short.Target != "v=spf1 -all" {
+		t.Errorf("expected short TXT values to be stored as-is, got %s", short.Target)
+	}
+
+	if err := ValidateDomainRecord(DomainRecordCreateOptions{Type: RecordTypeTXT, Target: strings.Repeat("k", 300)}); err == nil {
+		t.Error("expected an error for an unchunked long TXT value")
+	}
+
+	update := txt.GetUpdateOptions()
+	if update.Target != txt.Target || update.Type != RecordTypeTXT {
+		t.Errorf("unexpected update options: %+v", update)
+	}
+}
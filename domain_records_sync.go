@@ -0,0 +1,301 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// DomainRecordSyncAction is the kind of change made by SyncDomainRecords
+type DomainRecordSyncAction string
+
+// DomainRecordSyncAction constants are the changes SyncDomainRecords can make
+const (
+	DomainRecordSyncCreate DomainRecordSyncAction = "create"
+	DomainRecordSyncUpdate DomainRecordSyncAction = "update"
+	DomainRecordSyncDelete DomainRecordSyncAction = "delete"
+)
+
+// DomainRecordSyncChange is a single change made by SyncDomainRecords
+type DomainRecordSyncChange struct {
+	Action DomainRecordSyncAction
+
+	// RecordID is the ID of the updated or deleted record, or of the created record
+	// once it has been applied
+	RecordID int
+
+	Current *DomainRecord
+	Desired *DomainRecordCreateOptions
+}
+
+func (c DomainRecordSyncChange) String() string {
+	if c.Desired != nil {
+		return fmt.Sprintf("%s %s %s %s", c.Action, c.Desired.Type, domainRecordDisplayName(c.Desired.Name), c.Desired.Target)
+	}
+
+	return fmt.Sprintf("%s %s %s %s", c.Action, c.Current.Type, domainRecordDisplayName(c.Current.Name), c.Current.Target)
+}
+
+// DiffDomainRecords computes the changes needed to turn the current records into the desired
+// records. Records are grouped by type and name, and by service and protocol for SRV records;
+// within a group, identical records are kept, remaining records are updated in place and any
+// surplus is created or deleted. Deletions come first so that records such as CNAMEs, which
+// cannot coexist with other records of the same name, can replace them.
+//
+// Only record types that appear in the desired records are managed; current records of other
+// types are left alone unless a desired CNAME takes over their name. This keeps records that
+// the desired set cannot represent, such as PTR records dropped by ParseZoneFile.
+func DiffDomainRecords(current []DomainRecord, desired []DomainRecordCreateOptions) []DomainRecordSyncChange {
+	currentGroups := make(map[string][]int)
+	desiredGroups := make(map[string][]int)
+	managedTypes := make(map[DomainRecordType]bool)
+	cnameNames := make(map[string]bool)
+
+	var keys []string
+
+	for i, record := range current {
+		key := domainRecordKey(record.Type, record.Name, record.Service, record.Protocol)
+		currentGroups[key] = append(currentGroups[key], i)
+	}
+
+	for i, record := range desired {
+		key := domainRecordKey(record.Type, record.Name, record.Service, record.Protocol)
+
+		if _, ok := desiredGroups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		desiredGroups[key] = append(desiredGroups[key], i)
+		managedTypes[record.Type] = true
+
+		if record.Type == RecordTypeCNAME {
+			cnameNames[domainRecordNormalizedName(record.Name)] = true
+		}
+	}
+
+	for i, record := range current {
+		if !managedTypes[record.Type] && !cnameNames[domainRecordNormalizedName(record.Name)] {
+			continue
+		}
+
+		key := domainRecordKey(record.Type, record.Name, record.Service, record.Protocol)
+		if _, ok := desiredGroups[key]; !ok && currentGroups[key][0] == i {
+			keys = append(keys, key)
+		}
+	}
+
+	var deletes, updates, creates []DomainRecordSyncChange
+
+	for _, key := range keys {
+		have := currentGroups[key]
+		want := desiredGroups[key]
+
+		// Remove records that are already in the desired state
+		remaining := make([]int, 0, len(have))
+
+		for _, i := range have {
+			match := -1
+
+			for n, j := range want {
+				if domainRecordEqual(current[i], desired[j]) {
+					match = n
+					break
+				}
+			}
+
+			if match < 0 {
+				remaining = append(remaining, i)
+				continue
+			}
+
+			want = append(want[:match:match], want[match+1:]...)
+		}
+
+		for n, i := range remaining {
+			record := current[i]
+
+			if n < len(want) {
+				desiredRecord := desired[want[n]]
+				updates = append(updates, DomainRecordSyncChange{
+					Action: DomainRecordSyncUpdate, RecordID: record.ID, Current: &record, Desired: &desiredRecord,
+				})
+
+				continue
+			}
+
+			deletes = append(deletes, DomainRecordSyncChange{
+				Action: DomainRecordSyncDelete, RecordID: record.ID, Current: &record,
+			})
+		}
+
+		for n := len(remaining); n < len(want); n++ {
+			desiredRecord := desired[want[n]]
+			creates = append(creates, DomainRecordSyncChange{
+				Action: DomainRecordSyncCreate, Desired: &desiredRecord,
+			})
+		}
+	}
+
+	return append(append(deletes, updates...), creates...)
+}
+
+// SyncDomainRecords changes the records of the Domain with the provided ID to match the desired
+// records, such as those parsed by ParseZoneFile. Records are only deleted within the types
+// managed by DiffDomainRecords. When dryRun is set the changes are computed
+// but not applied. Changes made before an error are included in the result.
+func (c *Client) SyncDomainRecords(
+	ctx context.Context,
+	domainID int,
+	desired []DomainRecordCreateOptions,
+	dryRun bool,
+) ([]DomainRecordSyncChange, error) {
+	current, err := c.ListDomainRecords(ctx, domainID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := DiffDomainRecords(current, desired)
+	if dryRun {
+		return changes, nil
+	}
+
+	for i, change := range changes {
+		switch change.Action {
+		case DomainRecordSyncDelete:
+			err = c.DeleteDomainRecord(ctx, domainID, change.RecordID)
+		case DomainRecordSyncUpdate:
+			_, err = c.UpdateDomainRecord(ctx, domainID, change.RecordID, change.Desired.GetUpdateOptions())
+		case DomainRecordSyncCreate:
+			var record *DomainRecord
+
+			if record, err = c.CreateDomainRecord(ctx, domainID, *change.Desired); err == nil {
+				changes[i].RecordID = record.ID
+			}
+		}
+
+		if err != nil {
+			return changes[:i], fmt.Errorf("failed to %s: %w", change, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// domainRecordKey groups records that describe the same owner name and type
+func domainRecordKey(recordType DomainRecordType, name string, service, protocol *string) string {
+	name = domainRecordNormalizedName(name)
+
+	if recordType != RecordTypeSRV {
+		return fmt.Sprintf("%s|%s", recordType, name)
+	}
+
+	svc := strings.ToLower(strings.TrimPrefix(domainRecordOptionalField(service), "_"))
+	proto := strings.ToLower(strings.TrimPrefix(domainRecordOptionalField(protocol), "_"))
+
+	// SRV names may include the service and protocol labels
+	name = strings.TrimPrefix(name, fmt.Sprintf("_%s._%s", svc, proto))
+	name = strings.TrimPrefix(name, ".")
+
+	return fmt.Sprintf("%s|%s|%s|%s", recordType, name, svc, proto)
+}
+
+// domainRecordEqual reports whether the current record already matches the desired record
+func domainRecordEqual(current DomainRecord, desired DomainRecordCreateOptions) bool {
+	if roundDomainRecordTTL(desired.TTLSec) != current.TTLSec {
+		return false
+	}
+
+	if !domainRecordTargetEqual(current.Type, current.Target, desired.Target) {
+		return false
+	}
+
+	intEqual := func(have int, want *int) bool {
+		return want == nil || *want == have
+	}
+
+	switch current.Type {
+	case RecordTypeMX:
+		return intEqual(current.Priority, desired.Priority)
+	case RecordTypeSRV:
+		return intEqual(current.Priority, desired.Priority) &&
+			intEqual(current.Weight, desired.Weight) &&
+			intEqual(current.Port, desired.Port)
+	case RecordTypeCAA:
+		return strings.EqualFold(domainRecordOptionalField(current.Tag), domainRecordOptionalField(desired.Tag))
+	}
+
+	return true
+}
+
+func domainRecordTargetEqual(recordType DomainRecordType, current, desired string) bool {
+	switch recordType {
+	case RecordTypeA, RecordTypeAAAA:
+		a, errA := netip.ParseAddr(current)
+		b, errB := netip.ParseAddr(desired)
+
+		if errA == nil && errB == nil {
+			return a == b
+		}
+	case RecordTypeCNAME, RecordTypeNS, RecordTypeMX, RecordTypeSRV, RecordTypePTR:
+		return strings.EqualFold(strings.TrimSuffix(current, "."), strings.TrimSuffix(desired, "."))
+	case RecordTypeTXT:
+		return txtTargetValue(current) == txtTargetValue(desired)
+	}
+
+	return current == desired
+}
+
+// domainRecordTTLs are the TTLs accepted by the Linode API; other values are rounded to the
+// nearest accepted value. 0 uses the Domain's default TTL.
+var domainRecordTTLs = []int{
+	300, 3600, 7200, 14400, 28800, 57600, 86400, 172800, 345600, 604800, 1209600, 2419200,
+}
+
+// roundDomainRecordTTL returns the TTL the Linode API stores for the requested TTL
+func roundDomainRecordTTL(ttl int) int {
+	if ttl <= 0 {
+		return 0
+	}
+
+	best := domainRecordTTLs[0]
+
+	for _, allowed := range domainRecordTTLs {
+		if domainRecordTTLDistance(allowed, ttl) < domainRecordTTLDistance(best, ttl) {
+			best = allowed
+		}
+	}
+
+	return best
+}
+
+// domainRecordTTLDistance returns how far apart two TTLs are
+func domainRecordTTLDistance(a, b int) int {
+	if a < b {
+		return b - a
+	}
+
+	return a - b
+}
+
+// domainRecordNormalizedName returns the name of a record for case-insensitive comparisons
+func domainRecordNormalizedName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// domainRecordOptionalField returns the value of an optional record field, or "" if unset
+func domainRecordOptionalField(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func domainRecordDisplayName(name string) string {
+	if name == "" {
+		return "@"
+	}
+
+	return name
+}
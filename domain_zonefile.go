@@ -0,0 +1,594 @@
+package linodego
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ZoneFileSkippedRecord is a zone file entry that was not converted into a record
+type ZoneFileSkippedRecord struct {
+	Line   int
+	Type   string
+	Text   string
+	Reason string
+}
+
+// ZoneFile is a parsed RFC 1035 zone file
+type ZoneFile struct {
+	// Origin is the domain the records are relative to, without a trailing dot
+	Origin string
+
+	Records []DomainRecordCreateOptions
+	Skipped []ZoneFileSkippedRecord
+}
+
+// zoneFileEntry is a logical zone file line with parentheses and comments resolved
+type zoneFileEntry struct {
+	line       int
+	tokens     []string
+	blankOwner bool
+}
+
+// ParseZoneFile parses an RFC 1035 zone file for the provided domain into records accepted
+// by CreateDomainRecord. A, AAAA, CNAME, MX, TXT, SRV, CAA and NS records are converted;
+// SOA records, the Linode-managed apex NS records, records outside of the domain and
+// unsupported record types are reported as skipped.
+func ParseZoneFile(r io.Reader, domain string) (*ZoneFile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := tokenizeZoneFile(string(data))
+	if err != nil {
+		return nil, err
+	}
+
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	zone := &ZoneFile{Origin: domain}
+
+	origin := domain
+	defaultTTL := 0
+	owner := domain
+
+	for _, entry := range entries {
+		tokens := entry.tokens
+
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN requires a domain", entry.line)
+			}
+
+			origin = zoneFileAbsoluteName(tokens[1], origin)
+
+			continue
+		case "$TTL":
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("line %d: $TTL requires a value", entry.line)
+			}
+
+			if defaultTTL, err = parseZoneFileTTL(tokens[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", entry.line, err)
+			}
+
+			continue
+		case "$INCLUDE", "$GENERATE":
+			zone.Skipped = append(zone.Skipped, ZoneFileSkippedRecord{
+				Line: entry.line, Type: tokens[0], Text: strings.Join(tokens, " "), Reason: "directive is not supported",
+			})
+
+			continue
+		}
+
+		if !entry.blankOwner {
+			owner = zoneFileAbsoluteName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+
+		ttl := defaultTTL
+
+		// The TTL and class may appear in either order before the type
+		for len(tokens) > 0 {
+			if t, err := parseZoneFileTTL(tokens[0]); err == nil {
+				ttl = t
+			} else if !strings.EqualFold(tokens[0], "IN") {
+				break
+			}
+
+			tokens = tokens[1:]
+		}
+
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing record type", entry.line)
+		}
+
+		recordType := strings.ToUpper(tokens[0])
+		rdata := tokens[1:]
+
+		skip := func(reason string) {
+			zone.Skipped = append(zone.Skipped, ZoneFileSkippedRecord{
+				Line: entry.line, Type: recordType, Text: strings.Join(entry.tokens, " "), Reason: reason,
+			})
+		}
+
+		name, inDomain := zoneFileRelativeName(owner, domain)
+		if !inDomain {
+			skip(fmt.Sprintf("%s is outside of %s", owner, domain))
+			continue
+		}
+
+		record, err := newZoneFileRecord(DomainRecordType(recordType), name, rdata, origin)
+		if err != nil {
+			if errors.Is(err, errZoneFileRecordSkipped) {
+				reason := fmt.Sprintf("%s records are not supported", recordType)
+				if recordType == "SOA" {
+					reason = "the SOA record is managed by the domain"
+				}
+
+				skip(reason)
+
+				continue
+			}
+
+			return nil, fmt.Errorf("line %d: %w", entry.line, err)
+		}
+
+		if record.Type == RecordTypeNS && name == "" && strings.HasSuffix(record.Target, ".linode.com") {
+			skip("apex NS records for Linode name servers are managed automatically")
+			continue
+		}
+
+		record.TTLSec = ttl
+		zone.Records = append(zone.Records, *record)
+	}
+
+	return zone, nil
+}
+
+var errZoneFileRecordSkipped = errors.New("record type is not supported")
+
+//nolint:gocognit
+func newZoneFileRecord(recordType DomainRecordType, name string, rdata []string, origin string) (*DomainRecordCreateOptions, error) {
+	record := &DomainRecordCreateOptions{Type: recordType, Name: name}
+
+	need := func(n int) error {
+		if len(rdata) < n {
+			return fmt.Errorf("%s record requires %d values, got %d", recordType, n, len(rdata))
+		}
+
+		return nil
+	}
+
+	parseInt := func(s, field string) (*int, error) {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 || i > 65535 {
+			return nil, fmt.Errorf("invalid %s record %s %q", recordType, field, s)
+		}
+
+		return &i, nil
+	}
+
+	var err error
+
+	switch recordType {
+	case RecordTypeA, RecordTypeAAAA:
+		if err := need(1); err != nil {
+			return nil, err
+		}
+
+		addr, err := netip.ParseAddr(rdata[0])
+		if err != nil || addr.Is4() != (recordType == RecordTypeA) {
+			return nil, fmt.Errorf("invalid %s record address %q", recordType, rdata[0])
+		}
+
+		record.Target = addr.String()
+	case RecordTypeCNAME, RecordTypeNS:
+		if err := need(1); err != nil {
+			return nil, err
+		}
+
+		record.Target = zoneFileAbsoluteName(rdata[0], origin)
+	case RecordTypeMX:
+		if err := need(2); err != nil {
+			return nil, err
+		}
+
+		if record.Priority, err = parseInt(rdata[0], "priority"); err != nil {
+			return nil, err
+		}
+
+		record.Target = zoneFileAbsoluteName(rdata[1], origin)
+	case RecordTypeTXT:
+		if err := need(1); err != nil {
+			return nil, err
+		}
+
+		// Character strings are concatenated into a single value and stored in the same
+		// form as NewTXTRecord, so that parsed and built records compare equal
+		record.Target = formatTXTTarget(strings.Join(rdata, ""))
+	case RecordTypeSRV:
+		if err := need(4); err != nil {
+			return nil, err
+		}
+
+		labels := strings.SplitN(name, ".", 3)
+		if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return nil, fmt.Errorf("SRV record name %q must start with _service._protocol", name)
+		}
+
+		record.Service = Pointer(strings.TrimPrefix(labels[0], "_"))
+		record.Protocol = Pointer(strings.ToLower(strings.TrimPrefix(labels[1], "_")))
+
+		record.Name = ""
+		if len(labels) == 3 {
+			record.Name = labels[2]
+		}
+
+		if record.Priority, err = parseInt(rdata[0], "priority"); err != nil {
+			return nil, err
+		}
+
+		if record.Weight, err = parseInt(rdata[1], "weight"); err != nil {
+			return nil, err
+		}
+
+		if record.Port, err = parseInt(rdata[2], "port"); err != nil {
+			return nil, err
+		}
+
+		record.Target = zoneFileAbsoluteName(rdata[3], origin)
+	case RecordTypeCAA:
+		if err := need(3); err != nil {
+			return nil, err
+		}
+
+		record.Tag = Pointer(strings.ToLower(rdata[1]))
+		record.Target = rdata[2]
+	default:
+		return nil, errZoneFileRecordSkipped
+	}
+
+	return record, nil
+}
+
+// FormatZoneFile renders the records of the provided domain as an RFC 1035 zone file.
+// Records with a TTL of 0 use the zone's default TTL.
+func FormatZoneFile(domain string, defaultTTL int, records []DomainRecord) string {
+	domain = strings.TrimSuffix(domain, ".")
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", domain)
+
+	if defaultTTL > 0 {
+		fmt.Fprintf(&b, "$TTL %d\n", defaultTTL)
+	}
+
+	sorted := append([]DomainRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	for _, record := range sorted {
+		name := record.Name
+		if name == "" {
+			name = "@"
+		}
+
+		var rdata string
+
+		switch record.Type {
+		case RecordTypeCNAME, RecordTypeNS, RecordTypePTR:
+			rdata = zoneFileFQDN(record.Target)
+		case RecordTypeMX:
+			rdata = fmt.Sprintf("%d %s", record.Priority, zoneFileFQDN(record.Target))
+		case RecordTypeTXT:
+			rdata = quoteZoneFileTXT(record.Target)
+		case RecordTypeSRV:
+			if record.Service != nil && record.Protocol != nil {
+				name = fmt.Sprintf("_%s._%s", strings.TrimPrefix(*record.Service, "_"), strings.TrimPrefix(*record.Protocol, "_"))
+				if record.Name != "" && !strings.HasPrefix(record.Name, "_") {
+					name += "." + record.Name
+				}
+			}
+
+			rdata = fmt.Sprintf("%d %d %d %s", record.Priority, record.Weight, record.Port, zoneFileFQDN(record.Target))
+		case RecordTypeCAA:
+			tag := ""
+			if record.Tag != nil {
+				tag = *record.Tag
+			}
+
+			rdata = fmt.Sprintf("0 %s %s", tag, quoteZoneFileString(record.Target))
+		default:
+			rdata = record.Target
+		}
+
+		ttl := ""
+		if record.TTLSec > 0 {
+			ttl = strconv.Itoa(record.TTLSec)
+		}
+
+		fmt.Fprintf(&b, "%s\t%s\tIN\t%s\t%s\n", name, ttl, record.Type, rdata)
+	}
+
+	return b.String()
+}
+
+// formatTXTTarget returns the target of a TXT record holding the value. Values that fit in a
+// single character string are stored as-is; longer values are stored as quoted character
+// strings of at most 255 bytes each.
+func formatTXTTarget(value string) string {
+	if len(value) <= DomainRecordMaxTXTChunk {
+		return value
+	}
+
+	return quoteZoneFileTXT(value)
+}
+
+// txtTargetValue returns the value held by a TXT record target, joining quoted character strings
+func txtTargetValue(target string) string {
+	if strings.HasPrefix(strings.TrimSpace(target), `"`) {
+		if entries, err := tokenizeZoneFile(target); err == nil && len(entries) == 1 {
+			return strings.Join(entries[0].tokens, "")
+		}
+	}
+
+	return target
+}
+
+// quoteZoneFileTXT returns a TXT record target as quoted character strings of at most 255 bytes
+func quoteZoneFileTXT(target string) string {
+	value := txtTargetValue(target)
+
+	var chunks []string
+
+	for len(value) > DomainRecordMaxTXTChunk {
+		chunks = append(chunks, quoteZoneFileString(value[:DomainRecordMaxTXTChunk]))
+		value = value[DomainRecordMaxTXTChunk:]
+	}
+
+	chunks = append(chunks, quoteZoneFileString(value))
+
+	return strings.Join(chunks, " ")
+}
+
+// quoteZoneFileString quotes a character string, escaping quotes and backslashes and writing
+// bytes outside of printable ASCII as RFC 1035 \DDD decimal escapes
+func quoteZoneFileString(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c > 0x7e:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func zoneFileFQDN(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+
+	return name + "."
+}
+
+// zoneFileAbsoluteName resolves a zone file name against the origin, returning it without a trailing dot
+func zoneFileAbsoluteName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	case origin == "":
+		return strings.ToLower(name)
+	}
+
+	return strings.ToLower(name + "." + origin)
+}
+
+// zoneFileRelativeName returns the name relative to the domain, or false if it is outside of it
+func zoneFileRelativeName(name, domain string) (string, bool) {
+	if name == domain {
+		return "", true
+	}
+
+	if strings.HasSuffix(name, "."+domain) {
+		return strings.TrimSuffix(name, "."+domain), true
+	}
+
+	return "", false
+}
+
+// parseZoneFileTTL parses a TTL in seconds or with BIND unit suffixes such as 1h30m
+func parseZoneFileTTL(s string) (int, error) {
+	if s == "" || !unicode.IsDigit(rune(s[0])) {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+
+	if ttl, err := strconv.Atoi(s); err == nil {
+		return ttl, nil
+	}
+
+	units := map[rune]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, current := 0, 0
+
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsDigit(r) {
+			current = current*10 + int(r-'0')
+			continue
+		}
+
+		unit, ok := units[r]
+		if !ok {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+
+		total += current * unit
+		current = 0
+	}
+
+	return total + current, nil
+}
+
+// tokenizeZoneFile splits a zone file into logical entries, joining lines enclosed in
+// parentheses, removing comments, unquoting character strings and decoding \DDD escapes
+//
+//nolint:gocognit
+func tokenizeZoneFile(data string) ([]zoneFileEntry, error) {
+	var (
+		entries []zoneFileEntry
+		current *zoneFileEntry
+		token   strings.Builder
+		inToken bool
+		quoted  bool
+		escaped bool
+		digits  []byte
+		comment bool
+		depth   int
+	)
+
+	line := 1
+	lineStart := true
+
+	flush := func() {
+		if !inToken {
+			return
+		}
+
+		current.tokens = append(current.tokens, token.String())
+		token.Reset()
+
+		inToken = false
+	}
+
+	endEntry := func() {
+		flush()
+
+		if current != nil && len(current.tokens) > 0 {
+			entries = append(entries, *current)
+		}
+
+		current = nil
+	}
+
+	for _, r := range data {
+		if current == nil {
+			current = &zoneFileEntry{line: line}
+			current.blankOwner = lineStart && (r == ' ' || r == '\t')
+		}
+
+		switch {
+		case comment:
+			if r == '\n' {
+				comment = false
+			} else {
+				continue
+			}
+		case escaped:
+			switch {
+			case r >= '0' && r <= '9':
+				// A \DDD escape is the decimal value of a single byte
+				if digits = append(digits, byte(r)); len(digits) < 3 {
+					continue
+				}
+
+				n, _ := strconv.Atoi(string(digits))
+				if n > 255 {
+					return nil, fmt.Errorf("line %d: invalid escape \\%s", line, digits)
+				}
+
+				token.WriteByte(byte(n))
+			case len(digits) > 0:
+				return nil, fmt.Errorf("line %d: invalid escape \\%s", line, digits)
+			default:
+				token.WriteRune(r)
+			}
+
+			escaped, digits = false, digits[:0]
+
+			continue
+		case quoted:
+			switch r {
+			case '\\':
+				escaped = true
+			case '"':
+				quoted = false
+			default:
+				token.WriteRune(r)
+			}
+
+			continue
+		}
+
+		lineStart = false
+
+		switch r {
+		case '\n':
+			line++
+			lineStart = true
+
+			if depth == 0 {
+				endEntry()
+			} else {
+				flush()
+			}
+		case ';':
+			flush()
+
+			comment = true
+		case '"':
+			quoted, inToken = true, true
+		case '(':
+			flush()
+
+			depth++
+		case ')':
+			flush()
+
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("line %d: unbalanced parentheses", line)
+			}
+		case '\\':
+			escaped, inToken = true, true
+		case ' ', '\t', '\r':
+			flush()
+		default:
+			token.WriteRune(r)
+
+			inToken = true
+		}
+	}
+
+	if quoted || depth != 0 {
+		return nil, fmt.Errorf("line %d: unterminated quote or parentheses", line)
+	}
+
+	endEntry()
+
+	return entries, nil
+}
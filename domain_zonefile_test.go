@@ -0,0 +1,153 @@
+package linodego
+
+import (
+	"strings"
+	"testing"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 3600
+@	IN	SOA	ns1.linode.com. admin.example.com. (
+		2024010101 ; serial
+		14400 14400 1209600 86400 )
+@		IN	NS	ns1.linode.com.
+@	300	IN	A	192.0.2.10
+www		IN	CNAME	@
+		IN	TXT	"part one " "part two"
+@		IN	MX	10 mail
+_sip._tcp	IN	SRV	10 20 5060 sip.example.com.
+@		IN	CAA	0 ISSUE "letsencrypt.org"
+@		IN	HINFO	"cpu" "os"
+`
+
+func TestParseZoneFile(t *testing.T) {
+	zone, err := ParseZoneFile(strings.NewReader(testZoneFile), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(zone.Records) != 6 {
+		t.Fatalf("expected 6 records, got %d: %+v", len(zone.Records), zone.Records)
+	}
+
+	if len(zone.Skipped) != 3 {
+		t.Errorf("expected SOA, NS and HINFO to be skipped, got %+v", zone.Skipped)
+	}
+
+	byType := make(map[DomainRecordType]DomainRecordCreateOptions)
+	for _, record := range zone.Records {
+		byType[record.Type] = record
+	}
+
+	if a := byType[RecordTypeA]; a.TTLSec != 300 || a.Target != "192.0.2.10" {
+		t.Errorf("unexpected A record: %+v", a)
+	}
+
+	if txt := byType[RecordTypeTXT]; txt.Name != "www" || txt.Target != "part one part two" || txt.TTLSec != 3600 {
+		t.Errorf("unexpected TXT record: %+v", txt)
+	}
+
+	if mx := byType[RecordTypeMX]; mx.Target != "mail.example.com" || *mx.Priority != 10 {
+		t.Errorf("unexpected MX record: %+v", mx)
+	}
+
+	srv := byType[RecordTypeSRV]
+	if *srv.Service != "sip" || *srv.Protocol != "tcp" || srv.Name != "" || *srv.Port != 5060 {
+		t.Errorf("unexpected SRV record: %+v", srv)
+	}
+
+	if caa := byType[RecordTypeCAA]; *caa.Tag != "issue" || caa.Target != "letsencrypt.org" {
+		t.Errorf("unexpected CAA record: %+v", caa)
+	}
+}
+
+func TestFormatZoneFile_TXTChunks(t *testing.T) {
+	value := strings.Repeat("a", 300)
+	out := FormatZoneFile("example.com", 0, []DomainRecord{{Type: RecordTypeTXT, Target: value}})
+
+	zone, err := ParseZoneFile(strings.NewReader(out), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(zone.Records) != 1 || txtTargetValue(zone.Records[0].Target) != value {
+		t.Errorf("TXT record did not round trip: %s", out)
+	}
+
+	// Parsed long values are stored like NewTXTRecord stores them
+	if err := ValidateDomainRecord(zone.Records[0]); err != nil {
+		t.Errorf("parsed TXT record is invalid: %s", err)
+	}
+
+	if !domainRecordEqual(DomainRecord{Type: RecordTypeTXT, Target: zone.Records[0].Target}, zone.Records[0]) {
+		t.Error("expected the parsed TXT record to equal itself")
+	}
+}
+
+func TestFormatZoneFile_Escapes(t *testing.T) {
+	value := "café\t\"quoted\" \\ end"
+	out := FormatZoneFile("example.com", 0, []DomainRecord{{Type: RecordTypeTXT, Target: value}})
+
+	if !strings.Contains(out, `"caf\195\169\009\"quoted\" \\ end"`) {
+		t.Errorf("expected RFC 1035 escapes, got %s", out)
+	}
+
+	zone, err := ParseZoneFile(strings.NewReader(out), "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(zone.Records) != 1 || zone.Records[0].Target != value {
+		t.Errorf("TXT record did not round trip: %+v", zone.Records)
+	}
+
+	if _, err := ParseZoneFile(strings.NewReader(`@ IN TXT "bad \999"`+"\n"), "example.com"); err == nil {
+		t.Error("expected an error for an out of range escape")
+	}
+}
+
+func TestDiffDomainRecords(t *testing.T) {
+	current := []DomainRecord{
+		{ID: 1, Type: RecordTypeA, Name: "", Target: "192.0.2.10", TTLSec: 300},
+		{ID: 2, Type: RecordTypeA, Name: "www", Target: "192.0.2.11"},
+		{ID: 3, Type: RecordTypeMX, Name: "", Target: "Mail.example.com", Priority: 10},
+		{ID: 4, Type: RecordTypeTXT, Name: "old", Target: "stale"},
+		{ID: 5, Type: RecordTypePTR, Name: "10", Target: "www.example.com"},
+	}
+
+	desired := []DomainRecordCreateOptions{
+		{Type: RecordTypeA, Name: "", Target: "192.0.2.10", TTLSec: 290},
+		{Type: RecordTypeA, Name: "www", Target: "192.0.2.12"},
+		{Type: RecordTypeMX, Name: "", Target: "mail.example.com.", Priority: Pointer(10)},
+		{Type: RecordTypeAAAA, Name: "", Target: "2001:db8::1"},
+		{Type: RecordTypeTXT, Name: "", Target: "v=spf1 -all"},
+	}
+
+	changes := DiffDomainRecords(current, desired)
+
+	// The PTR record is not a managed type, so it is left alone
+	if len(changes) != 4 {
+		t.Fatalf("expected 3 changes, got %v", changes)
+	}
+
+	if changes[0].Action != DomainRecordSyncDelete || changes[0].RecordID != 4 {
+		t.Errorf("expected the TXT record to be deleted first, got %v", changes[0])
+	}
+
+	if changes[1].Action != DomainRecordSyncUpdate || changes[1].RecordID != 2 {
+		t.Errorf("expected the www record to be updated, got %v", changes[1])
+	}
+
+	if changes[2].Action != DomainRecordSyncCreate || changes[2].Desired.Type != RecordTypeAAAA {
+		t.Errorf("expected the AAAA record to be created, got %v", changes[2])
+	}
+
+	// A desired CNAME replaces the records of its name even when their type isn't managed
+	changes = DiffDomainRecords(current, []DomainRecordCreateOptions{
+		{Type: RecordTypeCNAME, Name: "10", Target: "www.example.com"},
+	})
+
+	if len(changes) != 2 || changes[0].Action != DomainRecordSyncDelete || changes[0].RecordID != 5 {
+		t.Errorf("expected the PTR record to be replaced by the CNAME, got %v", changes)
+	}
+}
@@ -145,6 +145,21 @@ type DomainUpdateOptions struct {
 	TTLSec int `json:"ttl_sec,omitempty"`
 }
 
+// DomainImportOptions fields are those accepted by ImportDomain
+type DomainImportOptions struct {
+	// The domain to import. It must be served by the remote nameserver and allow zone transfers to Linode's nameservers.
+	Domain string `json:"domain"`
+
+	// The remote nameserver that allows zone transfers (AXFR).
+	RemoteNameserver string `json:"remote_nameserver"`
+}
+
+// DomainCloneOptions fields are those accepted by CloneDomain
+type DomainCloneOptions struct {
+	// The new domain for the clone. It must be unique in our system.
+	Domain string `json:"domain"`
+}
+
 // DomainType constants start with DomainType and include Linode API Domain Type values
 type DomainType string
 
@@ -184,6 +199,25 @@ func (d Domain) GetUpdateOptions() (du DomainUpdateOptions) {
 	return
 }
 
+// GetCreateOptions converts a Domain to DomainCreateOptions for use in CreateDomain
+func (d Domain) GetCreateOptions() (dc DomainCreateOptions) {
+	dc.Domain = d.Domain
+	dc.Type = d.Type
+	dc.Group = d.Group
+	dc.Status = d.Status
+	dc.Description = d.Description
+	dc.SOAEmail = d.SOAEmail
+	dc.RetrySec = d.RetrySec
+	dc.MasterIPs = d.MasterIPs
+	dc.AXfrIPs = d.AXfrIPs
+	dc.Tags = d.Tags
+	dc.ExpireSec = d.ExpireSec
+	dc.RefreshSec = d.RefreshSec
+	dc.TTLSec = d.TTLSec
+
+	return
+}
+
 // ListDomains lists Domains
 func (c *Client) ListDomains(ctx context.Context, opts *ListOptions) ([]Domain, error) {
 	response, err := getPaginatedResults[Domain](ctx, c, "domains", opts)
@@ -234,6 +268,28 @@ func (c *Client) DeleteDomain(ctx context.Context, domainID int) error {
 	return err
 }
 
+// ImportDomain imports a Domain and its records from a remote nameserver using a zone transfer (AXFR)
+func (c *Client) ImportDomain(ctx context.Context, opts DomainImportOptions) (*Domain, error) {
+	e := "domains/import"
+	response, err := doPOSTRequest[Domain](ctx, c, e, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// CloneDomain clones the Domain with the specified id and its records into a new Domain
+func (c *Client) CloneDomain(ctx context.Context, domainID int, opts DomainCloneOptions) (*Domain, error) {
+	e := formatAPIPath("domains/%d/clone", domainID)
+	response, err := doPOSTRequest[Domain](ctx, c, e, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
 // GetDomainZoneFile gets the zone file for the last rendered zone for the specified domain.
 func (c *Client) GetDomainZoneFile(ctx context.Context, domainID int) (*DomainZoneFile, error) {
 	e := formatAPIPath("domains/%d/zone-file", domainID)
@@ -0,0 +1,284 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+)
+
+// FirewallDeviceSelector selects the Linodes and NodeBalancers a Firewall should be attached to.
+// A device is selected when it has every tag in Tags and its label matches LabelPattern.
+type FirewallDeviceSelector struct {
+	// Tags that a device must have, all of which must match
+	Tags []string
+
+	// LabelPattern is a shell pattern, as accepted by path.Match, matched against device labels
+	LabelPattern string
+
+	// Types restricts the kinds of devices that are selected and reconciled.
+	// Defaults to Linodes and NodeBalancers.
+	Types []FirewallDeviceType
+}
+
+// FirewallDeviceReconcileAction is the kind of change made by ReconcileFirewallDevices
+type FirewallDeviceReconcileAction string
+
+// FirewallDeviceReconcileAction constants are the changes ReconcileFirewallDevices can make
+const (
+	FirewallDeviceAttach FirewallDeviceReconcileAction = "attach"
+	FirewallDeviceDetach FirewallDeviceReconcileAction = "detach"
+)
+
+// FirewallDeviceReconcileChange is a single change made by ReconcileFirewallDevices
+type FirewallDeviceReconcileChange struct {
+	Action FirewallDeviceReconcileAction
+	Type   FirewallDeviceType
+
+	// EntityID and Label identify the Linode or NodeBalancer
+	EntityID int
+	Label    string
+
+	// DeviceID is the ID of the FirewallDevice; it is 0 for dry run attachments
+	DeviceID int
+}
+
+func (c FirewallDeviceReconcileChange) String() string {
+	return fmt.Sprintf("%s %s %d (%s)", c.Action, c.Type, c.EntityID, c.Label)
+}
+
+// FirewallDeviceReconcileResult lists the changes made by ReconcileFirewallDevices, in order
+type FirewallDeviceReconcileResult struct {
+	Changes []FirewallDeviceReconcileChange
+
+	// Unchanged is the number of selected devices that were already attached
+	Unchanged int
+}
+
+type firewallDeviceKey struct {
+	Type FirewallDeviceType
+	ID   int
+}
+
+// Validate checks that the selector selects by at least one criterion and has a valid pattern
+func (s FirewallDeviceSelector) Validate() error {
+	if len(s.Tags) == 0 && s.LabelPattern == "" {
+		return fmt.Errorf("selector must specify tags or a label pattern")
+	}
+
+	if _, err := path.Match(s.LabelPattern, ""); err != nil {
+		return fmt.Errorf("invalid label pattern %q: %w", s.LabelPattern, err)
+	}
+
+	for _, t := range s.Types {
+		if t != FirewallDeviceLinode && t != FirewallDeviceNodeBalancer {
+			return fmt.Errorf("unsupported device type %q", t)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether a device with the provided label and tags is selected
+func (s FirewallDeviceSelector) Matches(label string, tags []string) bool {
+	if s.LabelPattern != "" {
+		if ok, _ := path.Match(s.LabelPattern, label); !ok {
+			return false
+		}
+	}
+
+	have := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		have[tag] = true
+	}
+
+	for _, tag := range s.Tags {
+		if !have[tag] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// defaultFirewallDeviceTypes are reconciled when FirewallDeviceSelector.Types is empty
+var defaultFirewallDeviceTypes = []FirewallDeviceType{FirewallDeviceLinode, FirewallDeviceNodeBalancer}
+
+// selectsType reports whether devices of the provided type are reconciled. Types that are not
+// selected, including any the API adds later, are left untouched.
+func (s FirewallDeviceSelector) selectsType(t FirewallDeviceType) bool {
+	types := s.Types
+	if len(types) == 0 {
+		types = defaultFirewallDeviceTypes
+	}
+
+	for _, selected := range types {
+		if selected == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// listOptions returns ListOptions narrowing results to the first selector tag
+func (s FirewallDeviceSelector) listOptions() (*ListOptions, error) {
+	if len(s.Tags) == 0 {
+		return nil, nil
+	}
+
+	f := Filter{}
+	f.AddField(Eq, "tags", s.Tags[0])
+
+	filter, err := f.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListOptions{Filter: string(filter)}, nil
+}
+
+// ListFirewallSelectorDevices returns the Linodes and NodeBalancers selected by the selector
+// as FirewallDeviceEntities, ordered by type and ID
+func (c *Client) ListFirewallSelectorDevices(ctx context.Context, selector FirewallDeviceSelector) ([]FirewallDeviceEntity, error) {
+	if err := selector.Validate(); err != nil {
+		return nil, err
+	}
+
+	var result []FirewallDeviceEntity
+
+	if selector.selectsType(FirewallDeviceLinode) {
+		// ListOptions are updated with pagination state, so they are built per request
+		opts, err := selector.listOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		instances, err := c.ListInstances(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list instances: %w", err)
+		}
+
+		for _, instance := range instances {
+			if selector.Matches(instance.Label, instance.Tags) {
+				result = append(result, FirewallDeviceEntity{
+					ID: instance.ID, Type: FirewallDeviceLinode, Label: instance.Label,
+				})
+			}
+		}
+	}
+
+	if selector.selectsType(FirewallDeviceNodeBalancer) {
+		opts, err := selector.listOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		nodebalancers, err := c.ListNodeBalancers(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list NodeBalancers: %w", err)
+		}
+
+		for _, nodebalancer := range nodebalancers {
+			label := ""
+			if nodebalancer.Label != nil {
+				label = *nodebalancer.Label
+			}
+
+			if selector.Matches(label, nodebalancer.Tags) {
+				result = append(result, FirewallDeviceEntity{
+					ID: nodebalancer.ID, Type: FirewallDeviceNodeBalancer, Label: label,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+// ReconcileFirewallDevices attaches the Firewall with the provided ID to every Linode and
+// NodeBalancer selected by the selector, and detaches devices of the selected types that
+// no longer match. Attachments are made before detachments so that selected devices are
+// protected as early as possible. Changes made before an error are included in the result.
+func (c *Client) ReconcileFirewallDevices(
+	ctx context.Context,
+	firewallID int,
+	selector FirewallDeviceSelector,
+	dryRun bool,
+) (*FirewallDeviceReconcileResult, error) {
+	desired, err := c.ListFirewallSelectorDevices(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	attached, err := c.ListFirewallDevices(ctx, firewallID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[firewallDeviceKey]bool, len(attached))
+	for _, device := range attached {
+		current[firewallDeviceKey{device.Entity.Type, device.Entity.ID}] = true
+	}
+
+	result := &FirewallDeviceReconcileResult{}
+	wanted := make(map[firewallDeviceKey]bool, len(desired))
+
+	for _, entity := range desired {
+		key := firewallDeviceKey{entity.Type, entity.ID}
+		wanted[key] = true
+
+		if current[key] {
+			result.Unchanged++
+			continue
+		}
+
+		change := FirewallDeviceReconcileChange{
+			Action: FirewallDeviceAttach, Type: entity.Type, EntityID: entity.ID, Label: entity.Label,
+		}
+
+		if !dryRun {
+			device, err := c.CreateFirewallDevice(ctx, firewallID, FirewallDeviceCreateOptions{
+				ID: entity.ID, Type: entity.Type,
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to attach %s %d: %w", entity.Type, entity.ID, err)
+			}
+
+			change.DeviceID = device.ID
+		}
+
+		result.Changes = append(result.Changes, change)
+	}
+
+	for _, device := range attached {
+		key := firewallDeviceKey{device.Entity.Type, device.Entity.ID}
+		if wanted[key] || !selector.selectsType(device.Entity.Type) {
+			continue
+		}
+
+		if !dryRun {
+			if err := c.DeleteFirewallDevice(ctx, firewallID, device.ID); err != nil {
+				return result, fmt.Errorf("failed to detach %s %d: %w", device.Entity.Type, device.Entity.ID, err)
+			}
+		}
+
+		result.Changes = append(result.Changes, FirewallDeviceReconcileChange{
+			Action:   FirewallDeviceDetach,
+			Type:     device.Entity.Type,
+			EntityID: device.Entity.ID,
+			Label:    device.Entity.Label,
+			DeviceID: device.ID,
+		})
+	}
+
+	return result, nil
+}
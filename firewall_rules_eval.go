@@ -0,0 +1,197 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// FirewallDirection is the direction of traffic relative to the protected entity
+type FirewallDirection string
+
+// FirewallDirection constants are the directions a FirewallPacket can travel in
+const (
+	FirewallDirectionInbound  FirewallDirection = "inbound"
+	FirewallDirectionOutbound FirewallDirection = "outbound"
+)
+
+// FirewallPacket describes traffic to evaluate against firewall rules
+type FirewallPacket struct {
+	Direction FirewallDirection
+	Protocol  NetworkProtocol
+
+	// Port is the port of the protected entity for inbound traffic and the
+	// remote port for outbound traffic. It is ignored for ICMP and IPENCAP.
+	Port int
+
+	// Address is the source address for inbound traffic and the destination
+	// address for outbound traffic.
+	Address netip.Addr
+}
+
+// FirewallVerdict is the result of evaluating a FirewallPacket against a single rule set
+type FirewallVerdict struct {
+	Allowed bool
+	Action  string
+
+	// FirewallID and FirewallLabel identify the firewall when evaluating attached firewalls
+	FirewallID    int
+	FirewallLabel string
+
+	// RuleLabel and RuleIndex identify the matching rule; RuleIndex is -1 when the
+	// default policy applied.
+	RuleLabel     string
+	RuleIndex     int
+	DefaultPolicy bool
+}
+
+// FirewallEvaluation is the combined result of evaluating a FirewallPacket against
+// every enabled firewall attached to an entity
+type FirewallEvaluation struct {
+	Allowed  bool
+	Verdicts []FirewallVerdict
+}
+
+func (v FirewallVerdict) String() string {
+	source := fmt.Sprintf("rule %s", v.RuleLabel)
+	if v.DefaultPolicy {
+		source = "default policy"
+	}
+
+	if v.FirewallLabel != "" {
+		return fmt.Sprintf("%s by %s of firewall %s", v.Action, source, v.FirewallLabel)
+	}
+
+	return fmt.Sprintf("%s by %s", v.Action, source)
+}
+
+// EvaluateFirewallRules determines whether the packet is allowed by the rule set.
+// Rules are evaluated in order and the first matching rule decides; if no rule
+// matches, the default policy of the packet's direction applies.
+func EvaluateFirewallRules(rules FirewallRuleSet, packet FirewallPacket) (*FirewallVerdict, error) {
+	if !packet.Address.IsValid() {
+		return nil, fmt.Errorf("packet address is invalid")
+	}
+
+	spec, err := ParseFirewallRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		candidates []FirewallRuleSpec
+		policy     string
+	)
+
+	switch packet.Direction {
+	case FirewallDirectionInbound:
+		candidates, policy = spec.Inbound, spec.InboundPolicy
+	case FirewallDirectionOutbound:
+		candidates, policy = spec.Outbound, spec.OutboundPolicy
+	default:
+		return nil, fmt.Errorf("invalid packet direction %q", packet.Direction)
+	}
+
+	for i, rule := range candidates {
+		if !firewallRuleMatches(rule, packet) {
+			continue
+		}
+
+		action := strings.ToUpper(rule.Action)
+
+		return &FirewallVerdict{
+			Allowed:   action == FirewallActionAccept,
+			Action:    action,
+			RuleLabel: rule.Label,
+			RuleIndex: i,
+		}, nil
+	}
+
+	policy = strings.ToUpper(policy)
+
+	return &FirewallVerdict{
+		Allowed:       policy == FirewallActionAccept,
+		Action:        policy,
+		RuleIndex:     -1,
+		DefaultPolicy: true,
+	}, nil
+}
+
+// EvaluateFirewalls evaluates the packet against every enabled firewall. The packet is
+// only allowed if every firewall allows it.
+func EvaluateFirewalls(firewalls []Firewall, packet FirewallPacket) (*FirewallEvaluation, error) {
+	result := &FirewallEvaluation{Allowed: true}
+
+	for _, firewall := range firewalls {
+		if firewall.Status != FirewallEnabled {
+			continue
+		}
+
+		verdict, err := EvaluateFirewallRules(firewall.Rules, packet)
+		if err != nil {
+			return nil, fmt.Errorf("firewall %d: %w", firewall.ID, err)
+		}
+
+		verdict.FirewallID = firewall.ID
+		verdict.FirewallLabel = firewall.Label
+
+		result.Allowed = result.Allowed && verdict.Allowed
+		result.Verdicts = append(result.Verdicts, *verdict)
+	}
+
+	return result, nil
+}
+
+// EvaluateInstanceFirewalls evaluates the packet against the firewalls attached to the Linode with the provided ID
+func (c *Client) EvaluateInstanceFirewalls(ctx context.Context, linodeID int, packet FirewallPacket) (*FirewallEvaluation, error) {
+	firewalls, err := c.ListInstanceFirewalls(ctx, linodeID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return EvaluateFirewalls(firewalls, packet)
+}
+
+// EvaluateNodeBalancerFirewalls evaluates the packet against the firewalls attached to the NodeBalancer with the provided ID
+func (c *Client) EvaluateNodeBalancerFirewalls(ctx context.Context, nodebalancerID int, packet FirewallPacket) (*FirewallEvaluation, error) {
+	firewalls, err := c.ListNodeBalancerFirewalls(ctx, nodebalancerID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return EvaluateFirewalls(firewalls, packet)
+}
+
+func firewallRuleMatches(rule FirewallRuleSpec, packet FirewallPacket) bool {
+	if !strings.EqualFold(string(rule.Protocol), string(packet.Protocol)) {
+		return false
+	}
+
+	protocol := NetworkProtocol(strings.ToUpper(string(packet.Protocol)))
+
+	if (protocol == TCP || protocol == UDP) && rule.Ports != nil {
+		inRange := false
+
+		for _, r := range rule.Ports {
+			if r.Contains(packet.Port) {
+				inRange = true
+				break
+			}
+		}
+
+		if !inRange {
+			return false
+		}
+	}
+
+	addr := packet.Address.Unmap()
+
+	for _, prefix := range rule.Addresses {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,67 @@
+package linodego
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestEvaluateFirewallRules(t *testing.T) {
+	rules := FirewallRuleSet{
+		Inbound: []FirewallRule{
+			{
+				Action: "DROP", Label: "block-bad", Protocol: TCP,
+				Addresses: NetworkAddresses{IPv4: &[]string{"203.0.113.0/24"}},
+			},
+			{
+				Action: "ACCEPT", Label: "https", Ports: "443", Protocol: TCP,
+				Addresses: NetworkAddresses{IPv4: &[]string{"0.0.0.0/0"}},
+			},
+		},
+		InboundPolicy:  "DROP",
+		OutboundPolicy: "ACCEPT",
+	}
+
+	tests := []struct {
+		name    string
+		packet  FirewallPacket
+		allowed bool
+		rule    string
+		policy  bool
+	}{
+		{
+			name:    "allowed by rule",
+			packet:  FirewallPacket{FirewallDirectionInbound, TCP, 443, netip.MustParseAddr("198.51.100.7")},
+			allowed: true,
+			rule:    "https",
+		},
+		{
+			name:   "dropped by earlier rule",
+			packet: FirewallPacket{FirewallDirectionInbound, TCP, 443, netip.MustParseAddr("203.0.113.5")},
+			rule:   "block-bad",
+		},
+		{
+			name:   "dropped by inbound policy",
+			packet: FirewallPacket{FirewallDirectionInbound, UDP, 53, netip.MustParseAddr("198.51.100.7")},
+			policy: true,
+		},
+		{
+			name:    "allowed by outbound policy",
+			packet:  FirewallPacket{FirewallDirectionOutbound, TCP, 25, netip.MustParseAddr("198.51.100.7")},
+			allowed: true,
+			policy:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			verdict, err := EvaluateFirewallRules(rules, tc.packet)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if verdict.Allowed != tc.allowed || verdict.RuleLabel != tc.rule || verdict.DefaultPolicy != tc.policy {
+				t.Fatalf("unexpected verdict: %+v", verdict)
+			}
+		})
+	}
+}
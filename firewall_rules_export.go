@@ -0,0 +1,322 @@
+package linodego
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ExportIPTablesSave renders the rule set in iptables-save format. When ipv6 is set the
+// output is suitable for ip6tables-restore and only IPv6 addresses are included.
+// Because Cloud Firewalls are stateful, the output accepts established and related
+// traffic ahead of the exported rules.
+func ExportIPTablesSave(rules FirewallRuleSet, ipv6 bool) (string, error) {
+	spec, err := parseNormalizedFirewallRuleSet(rules)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "*filter")
+	fmt.Fprintf(&b, ":INPUT %s [0:0]\n", strings.ToUpper(spec.InboundPolicy))
+	fmt.Fprintln(&b, ":FORWARD ACCEPT [0:0]")
+	fmt.Fprintf(&b, ":OUTPUT %s [0:0]\n", strings.ToUpper(spec.OutboundPolicy))
+
+	chains := []struct {
+		name  string
+		iface string
+		rules []FirewallRuleSpec
+		addr  string
+	}{
+		{"INPUT", "-i lo", spec.Inbound, "-s"},
+		{"OUTPUT", "-o lo", spec.Outbound, "-d"},
+	}
+
+	for _, chain := range chains {
+		fmt.Fprintf(&b, "-A %s -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT\n", chain.name)
+		fmt.Fprintf(&b, "-A %s %s -j ACCEPT\n", chain.name, chain.iface)
+
+		for _, rule := range chain.rules {
+			match := iptablesProtocolMatch(rule, ipv6)
+			if match == "" {
+				continue
+			}
+
+			for _, prefix := range firewallPrefixesForFamily(rule.Addresses, ipv6) {
+				fmt.Fprintf(&b, "-A %s %s %s %s", chain.name, chain.addr, prefix, match)
+
+				if rule.Label != "" {
+					fmt.Fprintf(&b, " -m comment --comment %q", rule.Label)
+				}
+
+				fmt.Fprintf(&b, " -j %s\n", strings.ToUpper(rule.Action))
+			}
+		}
+	}
+
+	fmt.Fprintln(&b, "COMMIT")
+
+	return b.String(), nil
+}
+
+func iptablesProtocolMatch(rule FirewallRuleSpec, ipv6 bool) string {
+	switch rule.Protocol {
+	case TCP, UDP:
+		protocol := strings.ToLower(string(rule.Protocol))
+		if rule.Ports == nil {
+			return "-p " + protocol
+		}
+
+		return fmt.Sprintf("-p %s -m multiport --dports %s", protocol, joinFirewallPorts(rule.Ports, ":", ","))
+	case ICMP:
+		if ipv6 {
+			return "-p ipv6-icmp"
+		}
+
+		return "-p icmp"
+	case IPENCAP:
+		if ipv6 {
+			return ""
+		}
+
+		return "-p 4"
+	}
+
+	return ""
+}
+
+// ExportNFTables renders the rule set as an nftables ruleset in an inet table named filter
+func ExportNFTables(rules FirewallRuleSet) (string, error) {
+	spec, err := parseNormalizedFirewallRuleSet(rules)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintln(&b, "table inet filter {")
+
+	chains := []struct {
+		name   string
+		policy string
+		iface  string
+		field  string
+		rules  []FirewallRuleSpec
+	}{
+		{"input", spec.InboundPolicy, "iif", "saddr", spec.Inbound},
+		{"output", spec.OutboundPolicy, "oif", "daddr", spec.Outbound},
+	}
+
+	for i, chain := range chains {
+		if i > 0 {
+			fmt.Fprintln(&b)
+		}
+
+		fmt.Fprintf(&b, "\tchain %s {\n", chain.name)
+		fmt.Fprintf(&b, "\t\ttype filter hook %s priority 0; policy %s;\n", chain.name, strings.ToLower(chain.policy))
+		fmt.Fprintln(&b, "\t\tct state established,related accept")
+		fmt.Fprintf(&b, "\t\t%s \"lo\" accept\n", chain.iface)
+
+		for _, rule := range chain.rules {
+			for _, ipv6 := range []bool{false, true} {
+				prefixes := firewallPrefixesForFamily(rule.Addresses, ipv6)
+				if len(prefixes) == 0 {
+					continue
+				}
+
+				match := nftablesProtocolMatch(rule, ipv6)
+				if match == "" {
+					continue
+				}
+
+				family := "ip"
+				if ipv6 {
+					family = "ip6"
+				}
+
+				fmt.Fprintf(&b, "\t\t%s %s %s %s %s", family, chain.field, nftablesSet(prefixStrings(prefixes)), match, strings.ToLower(rule.Action))
+
+				if rule.Label != "" {
+					fmt.Fprintf(&b, " comment %q", rule.Label)
+				}
+
+				fmt.Fprintln(&b)
+			}
+		}
+
+		fmt.Fprintln(&b, "\t}")
+	}
+
+	fmt.Fprintln(&b, "}")
+
+	return b.String(), nil
+}
+
+func nftablesProtocolMatch(rule FirewallRuleSpec, ipv6 bool) string {
+	switch rule.Protocol {
+	case TCP, UDP:
+		protocol := strings.ToLower(string(rule.Protocol))
+		if rule.Ports == nil {
+			return "meta l4proto " + protocol
+		}
+
+		ports := make([]string, len(rule.Ports))
+		for i, r := range rule.Ports {
+			ports[i] = r.String()
+		}
+
+		return fmt.Sprintf("%s dport %s", protocol, nftablesSet(ports))
+	case ICMP:
+		if ipv6 {
+			return "meta l4proto ipv6-icmp"
+		}
+
+		return "meta l4proto icmp"
+	case IPENCAP:
+		if ipv6 {
+			return ""
+		}
+
+		return "meta l4proto ipencap"
+	}
+
+	return ""
+}
+
+func nftablesSet(values []string) string {
+	if len(values) == 1 {
+		return values[0]
+	}
+
+	return "{ " + strings.Join(values, ", ") + " }"
+}
+
+// ExportUFWCommands renders the rule set as a sequence of ufw commands. ICMP and IPENCAP
+// rules cannot be expressed with the ufw command line and are emitted as comments.
+func ExportUFWCommands(rules FirewallRuleSet) ([]string, error) {
+	spec, err := parseNormalizedFirewallRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := []string{
+		fmt.Sprintf("ufw default %s incoming", ufwPolicy(spec.InboundPolicy)),
+		fmt.Sprintf("ufw default %s outgoing", ufwPolicy(spec.OutboundPolicy)),
+	}
+
+	directions := []struct {
+		name  string
+		rules []FirewallRuleSpec
+	}{
+		{"in", spec.Inbound},
+		{"out", spec.Outbound},
+	}
+
+	for _, direction := range directions {
+		for _, rule := range direction.rules {
+			if rule.Protocol != TCP && rule.Protocol != UDP {
+				commands = append(commands, fmt.Sprintf(
+					"# %s: %s rules must be configured in /etc/ufw/before.rules", rule.Label, rule.Protocol))
+
+				continue
+			}
+
+			// 0.0.0.0/0 and ::/0 both become any, which only needs a single command
+			seen := make(map[string]bool)
+
+			for _, prefix := range rule.Addresses {
+				address := prefix.String()
+				if prefix.Bits() == 0 {
+					address = "any"
+				}
+
+				if seen[address] {
+					continue
+				}
+
+				seen[address] = true
+
+				from, to := address, "any"
+				if direction.name == "out" {
+					from, to = "any", address
+				}
+
+				command := fmt.Sprintf("ufw %s %s proto %s from %s to %s",
+					ufwPolicy(rule.Action), direction.name, strings.ToLower(string(rule.Protocol)), from, to)
+
+				if rule.Ports != nil {
+					command += " port " + joinFirewallPorts(rule.Ports, ":", ",")
+				}
+
+				if rule.Label != "" {
+					command += " comment " + ufwShellQuote(rule.Label)
+				}
+
+				commands = append(commands, command)
+			}
+		}
+	}
+
+	return commands, nil
+}
+
+// ufwShellQuote quotes s as a single shell word
+func ufwShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func ufwPolicy(action string) string {
+	if strings.EqualFold(action, FirewallActionAccept) {
+		return "allow"
+	}
+
+	return "deny"
+}
+
+func parseNormalizedFirewallRuleSet(rules FirewallRuleSet) (*FirewallRuleSetSpec, error) {
+	spec, err := ParseFirewallRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := spec.Normalize()
+
+	return &normalized, nil
+}
+
+// firewallPrefixesForFamily returns the prefixes belonging to the requested address family
+func firewallPrefixesForFamily(prefixes []netip.Prefix, ipv6 bool) []netip.Prefix {
+	result := make([]netip.Prefix, 0, len(prefixes))
+
+	for _, prefix := range prefixes {
+		if prefix.Addr().Is6() == ipv6 {
+			result = append(result, prefix)
+		}
+	}
+
+	return result
+}
+
+func prefixStrings(prefixes []netip.Prefix) []string {
+	result := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		result[i] = prefix.String()
+	}
+
+	return result
+}
+
+func joinFirewallPorts(ports []FirewallPortRange, rangeSep, sep string) string {
+	pieces := make([]string, len(ports))
+
+	for i, r := range ports {
+		if r.From == r.To {
+			pieces[i] = fmt.Sprint(r.From)
+		} else {
+			pieces[i] = fmt.Sprintf("%d%s%d", r.From, rangeSep, r.To)
+		}
+	}
+
+	return strings.Join(pieces, sep)
+}
@@ -0,0 +1,921 @@
+package linodego
+
+import (
+	"bufio"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// FirewallImportIssue describes a host firewall rule that could not be imported exactly
+type FirewallImportIssue struct {
+	Line   int
+	Text   string
+	Reason string
+}
+
+func (i FirewallImportIssue) String() string {
+	return fmt.Sprintf("line %d: %s (%s)", i.Line, i.Reason, i.Text)
+}
+
+// FirewallImportResult is the outcome of importing a host firewall configuration
+type FirewallImportResult struct {
+	Rules FirewallRuleSet
+
+	// Issues lists rules that were skipped or only approximated
+	Issues []FirewallImportIssue
+}
+
+// firewallImporter accumulates imported rules and issues
+type firewallImporter struct {
+	result  FirewallImportResult
+	counter map[FirewallDirection]int
+	rules   map[FirewallDirection][]FirewallRuleSpec
+}
+
+func newFirewallImporter() *firewallImporter {
+	return &firewallImporter{
+		result: FirewallImportResult{
+			Rules: FirewallRuleSet{
+				Inbound:        make([]FirewallRule, 0),
+				InboundPolicy:  FirewallActionAccept,
+				Outbound:       make([]FirewallRule, 0),
+				OutboundPolicy: FirewallActionAccept,
+			},
+		},
+		counter: make(map[FirewallDirection]int),
+		rules:   make(map[FirewallDirection][]FirewallRuleSpec),
+	}
+}
+
+// finish converts the imported rule specs into the result's rule set
+func (imp *firewallImporter) finish() *FirewallImportResult {
+	for _, spec := range imp.rules[FirewallDirectionInbound] {
+		imp.result.Rules.Inbound = append(imp.result.Rules.Inbound, spec.Rule())
+	}
+
+	for _, spec := range imp.rules[FirewallDirectionOutbound] {
+		imp.result.Rules.Outbound = append(imp.result.Rules.Outbound, spec.Rule())
+	}
+
+	return &imp.result
+}
+
+func (imp *firewallImporter) issue(line int, text, format string, args ...any) {
+	imp.result.Issues = append(imp.result.Issues, FirewallImportIssue{
+		Line:   line,
+		Text:   strings.TrimSpace(text),
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+func (imp *firewallImporter) setPolicy(direction FirewallDirection, policy string) {
+	if direction == FirewallDirectionInbound {
+		imp.result.Rules.InboundPolicy = policy
+	} else {
+		imp.result.Rules.OutboundPolicy = policy
+	}
+}
+
+// add appends a rule for every requested protocol, defaulting unset addresses to
+// anywhere in the given address families.
+func (imp *firewallImporter) add(
+	direction FirewallDirection,
+	action string,
+	protocols []NetworkProtocol,
+	ports []FirewallPortRange,
+	addresses []netip.Prefix,
+	families []int,
+	comment string,
+) {
+	if len(addresses) == 0 {
+		for _, family := range families {
+			if family == 6 {
+				addresses = append(addresses, netip.MustParsePrefix("::/0"))
+			} else {
+				addresses = append(addresses, netip.MustParsePrefix("0.0.0.0/0"))
+			}
+		}
+	}
+
+	for _, protocol := range protocols {
+		imp.counter[direction]++
+
+		label := sanitizeFirewallRuleLabel(comment)
+		if label == "" {
+			label = fmt.Sprintf("%s-%d", direction, imp.counter[direction])
+		} else if len(protocols) > 1 {
+			label = sanitizeFirewallRuleLabel(fmt.Sprintf("%s-%s", label, strings.ToLower(string(protocol))))
+		}
+
+		spec := FirewallRuleSpec{
+			Action:    action,
+			Label:     label,
+			Protocol:  protocol,
+			Addresses: addresses,
+		}
+
+		if comment != label {
+			spec.Description = truncateString(comment, FirewallRuleDescriptionMaxLength)
+		}
+
+		if protocol == TCP || protocol == UDP {
+			spec.Ports = ports
+		}
+
+		spec = spec.Normalize()
+		rules := imp.rules[direction]
+
+		// Host firewalls often need one rule per address family or address; merge
+		// consecutive rules that share a comment and otherwise match the same traffic.
+		if n := len(rules); n > 0 && comment != "" {
+			last := &rules[n-1]
+
+			if last.Label == spec.Label && last.Action == spec.Action && last.Protocol == spec.Protocol &&
+				FormatFirewallPorts(last.Ports) == FormatFirewallPorts(spec.Ports) {
+				last.Addresses = normalizeFirewallAddresses(append(last.Addresses, spec.Addresses...))
+				imp.counter[direction]--
+
+				continue
+			}
+		}
+
+		imp.rules[direction] = append(rules, spec)
+	}
+}
+
+var (
+	firewallLabelInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9\-_.]+`)
+	nftablesPolicyRegex       = regexp.MustCompile(`policy (\w+)`)
+	ufwColumnsRegex           = regexp.MustCompile(`\s{2,}`)
+	ufwDefaultsRegex          = regexp.MustCompile(`(\w+) \((incoming|outgoing)\)`)
+	ufwRuleNumberRegex        = regexp.MustCompile(`^\[\s*\d+\]\s*`)
+	ufwPortsRegex             = regexp.MustCompile(`^[\d,:]+$`)
+)
+
+func sanitizeFirewallRuleLabel(s string) string {
+	s = firewallLabelInvalidChars.ReplaceAllString(strings.TrimSpace(s), "-")
+	s = truncateString(s, FirewallRuleLabelMaxLength)
+	s = strings.Trim(s, "-_.")
+
+	if len(s) < FirewallRuleLabelMinLength {
+		return ""
+	}
+
+	return s
+}
+
+// truncateString shortens s to at most max bytes without splitting a multi-byte rune
+func truncateString(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+
+	cut := max
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return s[:cut]
+}
+
+func parseImportedAction(action string) (string, bool) {
+	switch strings.ToUpper(action) {
+	case "ACCEPT", "ALLOW":
+		return FirewallActionAccept, true
+	case "DROP", "DENY", "REJECT":
+		return FirewallActionDrop, true
+	}
+
+	return "", false
+}
+
+func parseImportedProtocol(protocol string) (NetworkProtocol, bool) {
+	switch strings.ToLower(protocol) {
+	case "tcp":
+		return TCP, true
+	case "udp":
+		return UDP, true
+	case "icmp", "icmpv6", "ipv6-icmp":
+		return ICMP, true
+	case "ipencap", "ipip", "4":
+		return IPENCAP, true
+	}
+
+	return "", false
+}
+
+func parseImportedPorts(s string) ([]FirewallPortRange, error) {
+	return ParseFirewallPorts(strings.NewReplacer(":", "-", " ", "").Replace(s))
+}
+
+// ImportIPTablesSave converts the filter table of iptables-save or ip6tables-save output
+// into a FirewallRuleSet. The INPUT chain becomes the inbound rules and the OUTPUT chain
+// the outbound rules. Set ipv6 when importing ip6tables-save output so that rules without
+// an address apply to all IPv6 addresses.
+func ImportIPTablesSave(data string, ipv6 bool) *FirewallImportResult {
+	imp := newFirewallImporter()
+
+	family := 4
+	if ipv6 {
+		family = 6
+	}
+
+	chains := map[string]FirewallDirection{
+		"INPUT":  FirewallDirectionInbound,
+		"OUTPUT": FirewallDirectionOutbound,
+	}
+
+	table := ""
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || line == "COMMIT":
+			continue
+		case strings.HasPrefix(line, "*"):
+			table = line[1:]
+			continue
+		case table != "filter":
+			continue
+		case strings.HasPrefix(line, ":"):
+			fields := strings.Fields(line[1:])
+			if len(fields) == 0 {
+				imp.issue(lineNo, line, "chain declaration has no name")
+				continue
+			}
+
+			if direction, ok := chains[fields[0]]; ok && len(fields) > 1 {
+				if policy, ok := parseImportedAction(fields[1]); ok {
+					imp.setPolicy(direction, policy)
+				}
+			}
+
+			continue
+		}
+
+		imp.importIPTablesRule(lineNo, line, chains, family)
+	}
+
+	return imp.finish()
+}
+
+//nolint:gocognit
+func (imp *firewallImporter) importIPTablesRule(lineNo int, line string, chains map[string]FirewallDirection, family int) {
+	args := splitShellFields(line)
+
+	var (
+		direction     FirewallDirection
+		chainKnown    bool
+		protocol      = ""
+		ports         []FirewallPortRange
+		remote        []netip.Prefix
+		action        string
+		comment       string
+		implicitState bool
+	)
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		next := ""
+
+		if i+1 < len(args) {
+			next = args[i+1]
+		}
+
+		switch arg {
+		case "-A", "--append":
+			direction, chainKnown = chains[next]
+			if !chainKnown {
+				imp.issue(lineNo, line, "chain %s is not supported", next)
+				return
+			}
+
+			i++
+		case "-p", "--protocol":
+			protocol = next
+			i++
+		case "-s", "--source", "-d", "--destination":
+			// Only the remote side of the connection can be matched
+			isSource := arg == "-s" || arg == "--source"
+			if isSource != (direction == FirewallDirectionInbound) {
+				imp.issue(lineNo, line, "matching the local address is not supported")
+				return
+			}
+
+			for _, address := range strings.Split(next, ",") {
+				prefix, err := ParseFirewallAddress(address)
+				if err != nil {
+					imp.issue(lineNo, line, "%s", err)
+					return
+				}
+
+				remote = append(remote, prefix)
+			}
+
+			i++
+		case "--dport", "--destination-port", "--dports", "--destination-ports":
+			parsed, err := parseImportedPorts(next)
+			if err != nil {
+				imp.issue(lineNo, line, "%s", err)
+				return
+			}
+
+			ports = append(ports, parsed...)
+			i++
+		case "-m", "--match":
+			i++
+		case "--state", "--ctstate":
+			implicitState = true
+			i++
+		case "--comment":
+			comment = next
+			i++
+		case "-j", "--jump":
+			var ok bool
+			if action, ok = parseImportedAction(next); !ok {
+				imp.issue(lineNo, line, "target %s is not supported", next)
+				return
+			}
+
+			if strings.EqualFold(next, "REJECT") {
+				imp.issue(lineNo, line, "REJECT was imported as DROP")
+			}
+
+			i++
+		case "-i", "--in-interface", "-o", "--out-interface":
+			imp.issue(lineNo, line, "interface matches are not supported")
+			return
+		case "!":
+			imp.issue(lineNo, line, "negated matches are not supported")
+			return
+		case "--sport", "--source-port", "--sports", "--source-ports":
+			imp.issue(lineNo, line, "source port matches are not supported")
+			return
+		case "--icmp-type", "--icmpv6-type", "--reject-with", "--tcp-flags", "--syn":
+			// Approximated by matching the whole protocol
+			if arg == "--icmp-type" || arg == "--icmpv6-type" {
+				imp.issue(lineNo, line, "ICMP type was widened to all ICMP traffic")
+			}
+
+			if arg != "--syn" {
+				i++
+			}
+
+			if arg == "--tcp-flags" {
+				i++
+			}
+		default:
+			imp.issue(lineNo, line, "option %s is not supported", arg)
+			return
+		}
+	}
+
+	if !chainKnown || action == "" {
+		imp.issue(lineNo, line, "rule has no supported chain or target")
+		return
+	}
+
+	if implicitState {
+		// Cloud Firewalls are stateful, so established and related traffic is always allowed
+		if protocol == "" && len(ports) == 0 && len(remote) == 0 {
+			imp.issue(lineNo, line, "connection state rules are implicit in Cloud Firewalls")
+			return
+		}
+
+		imp.issue(lineNo, line, "connection state match was ignored")
+	}
+
+	protocols, ok := importedProtocols(protocol)
+	if !ok {
+		imp.issue(lineNo, line, "protocol %s is not supported", protocol)
+		return
+	}
+
+	imp.add(direction, action, protocols, ports, remote, []int{family}, comment)
+}
+
+func importedProtocols(protocol string) ([]NetworkProtocol, bool) {
+	if protocol == "" || strings.EqualFold(protocol, "all") {
+		return []NetworkProtocol{TCP, UDP, ICMP}, true
+	}
+
+	parsed, ok := parseImportedProtocol(protocol)
+	if !ok {
+		return nil, false
+	}
+
+	return []NetworkProtocol{parsed}, true
+}
+
+// ImportNFTables converts a simple nftables ruleset, as printed by `nft list ruleset`,
+// into a FirewallRuleSet. Chains hooked into input become inbound rules and chains
+// hooked into output become outbound rules.
+//
+//nolint:gocognit
+func ImportNFTables(data string) *FirewallImportResult {
+	imp := newFirewallImporter()
+
+	var (
+		direction FirewallDirection
+		inChain   bool
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "table "):
+			continue
+		case strings.HasPrefix(line, "chain "):
+			inChain, direction = true, ""
+			continue
+		case line == "}":
+			continue
+		case !inChain:
+			continue
+		case strings.HasPrefix(line, "type "):
+			switch {
+			case strings.Contains(line, "hook input"):
+				direction = FirewallDirectionInbound
+			case strings.Contains(line, "hook output"):
+				direction = FirewallDirectionOutbound
+			}
+
+			if m := nftablesPolicyRegex.FindStringSubmatch(line); m != nil && direction != "" {
+				if policy, ok := parseImportedAction(m[1]); ok {
+					imp.setPolicy(direction, policy)
+				}
+			}
+
+			continue
+		case direction == "":
+			imp.issue(lineNo, line, "rules outside of input and output chains are not supported")
+			continue
+		}
+
+		imp.importNFTablesRule(lineNo, line, direction)
+	}
+
+	return imp.finish()
+}
+
+//nolint:gocognit
+func (imp *firewallImporter) importNFTablesRule(lineNo int, line string, direction FirewallDirection) {
+	tokens := splitNFTablesTokens(line)
+
+	var (
+		protocol string
+		ports    []FirewallPortRange
+		remote   []netip.Prefix
+		families []int
+		action   string
+		comment  string
+	)
+
+	remoteField, localField := "saddr", "daddr"
+	if direction == FirewallDirectionOutbound {
+		remoteField, localField = "daddr", "saddr"
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		next := ""
+
+		if i+1 < len(tokens) {
+			next = tokens[i+1]
+		}
+
+		switch tok {
+		case "ip", "ip6":
+			family := 4
+			if tok == "ip6" {
+				family = 6
+			}
+
+			families = append(families, family)
+
+			switch next {
+			case remoteField:
+				if i+2 >= len(tokens) {
+					imp.issue(lineNo, line, "missing address")
+					return
+				}
+
+				for _, address := range splitNFTablesSet(tokens[i+2]) {
+					prefix, err := ParseFirewallAddress(address)
+					if err != nil {
+						imp.issue(lineNo, line, "%s", err)
+						return
+					}
+
+					remote = append(remote, prefix)
+				}
+
+				i += 2
+			case localField:
+				imp.issue(lineNo, line, "matching the local address is not supported")
+				return
+			case "protocol":
+				if i+2 >= len(tokens) {
+					imp.issue(lineNo, line, "missing protocol")
+					return
+				}
+
+				protocol = tokens[i+2]
+				i += 2
+			default:
+				imp.issue(lineNo, line, "match %s %s is not supported", tok, next)
+				return
+			}
+		case "tcp", "udp":
+			protocol = tok
+
+			if next == "dport" && i+2 < len(tokens) {
+				for _, piece := range splitNFTablesSet(tokens[i+2]) {
+					parsed, err := parseImportedPorts(piece)
+					if err != nil {
+						imp.issue(lineNo, line, "%s", err)
+						return
+					}
+
+					ports = append(ports, parsed...)
+				}
+
+				i += 2
+			} else if next == "sport" {
+				imp.issue(lineNo, line, "source port matches are not supported")
+				return
+			}
+		case "icmp", "icmpv6":
+			protocol = "icmp"
+
+			if next == "type" {
+				imp.issue(lineNo, line, "ICMP type was widened to all ICMP traffic")
+				i += 2
+			}
+		case "meta":
+			if next == "l4proto" && i+2 < len(tokens) {
+				protocol = tokens[i+2]
+				i += 2
+
+				continue
+			}
+
+			imp.issue(lineNo, line, "meta %s is not supported", next)
+
+			return
+		case "ct":
+			if next == "state" {
+				imp.issue(lineNo, line, "connection state rules are implicit in Cloud Firewalls")
+				return
+			}
+
+			imp.issue(lineNo, line, "ct %s is not supported", next)
+
+			return
+		case "iif", "oif", "iifname", "oifname":
+			imp.issue(lineNo, line, "interface matches are not supported")
+			return
+		case "counter":
+		case "comment":
+			comment = strings.Trim(next, `"`)
+			i++
+		case "accept", "drop", "reject":
+			action, _ = parseImportedAction(tok)
+
+			if tok == "reject" {
+				imp.issue(lineNo, line, "reject was imported as DROP")
+			}
+
+			if tok == "reject" && next == "with" {
+				i += nftablesRejectWithLength(tokens[i+1:])
+			}
+		default:
+			imp.issue(lineNo, line, "expression %s is not supported", tok)
+			return
+		}
+	}
+
+	if action == "" {
+		imp.issue(lineNo, line, "rule has no verdict")
+		return
+	}
+
+	protocols, ok := importedProtocols(protocol)
+	if !ok {
+		imp.issue(lineNo, line, "protocol %s is not supported", protocol)
+		return
+	}
+
+	if len(families) == 0 {
+		families = []int{4, 6}
+	}
+
+	imp.add(direction, action, protocols, ports, remote, families, comment)
+}
+
+// nftablesRejectWithLength returns the number of tokens in a reject verdict's "with" clause,
+// such as "with icmp type port-unreachable", "with icmpx port-unreachable" or "with tcp reset"
+func nftablesRejectWithLength(tokens []string) int {
+	// "with" and the protocol
+	n := min(2, len(tokens))
+
+	if n == 2 && tokens[1] != "tcp" && n < len(tokens) && tokens[n] == "type" {
+		n++
+	}
+
+	// The reject type, or "reset" for tcp
+	if n < len(tokens) {
+		n++
+	}
+
+	return n
+}
+
+// ImportUFWStatus converts the output of `ufw status` or `ufw status verbose` into a
+// FirewallRuleSet. Default policies are only available in verbose output; otherwise they
+// are left empty, as they are unknown, and reported as an issue.
+//
+//nolint:gocognit
+func ImportUFWStatus(data string) *FirewallImportResult {
+	imp := newFirewallImporter()
+	imp.setPolicy(FirewallDirectionInbound, "")
+	imp.setPolicy(FirewallDirectionOutbound, "")
+
+	inTable := false
+	scanner := bufio.NewScanner(strings.NewReader(data))
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "Default:"):
+			for _, m := range ufwDefaultsRegex.FindAllStringSubmatch(line, -1) {
+				policy, ok := parseImportedAction(m[1])
+				if !ok {
+					continue
+				}
+
+				if m[2] == "incoming" {
+					imp.setPolicy(FirewallDirectionInbound, policy)
+				} else {
+					imp.setPolicy(FirewallDirectionOutbound, policy)
+				}
+			}
+
+			continue
+		case strings.HasPrefix(line, "--"):
+			inTable = true
+			continue
+		case !inTable:
+			continue
+		}
+
+		// Strip rule numbers printed by `ufw status numbered`
+		line = ufwRuleNumberRegex.ReplaceAllString(line, "")
+
+		comment := ""
+		if idx := strings.Index(line, " # "); idx >= 0 {
+			comment = strings.TrimSpace(line[idx+3:])
+			line = strings.TrimSpace(line[:idx])
+		}
+
+		fields := ufwColumnsRegex.Split(line, -1)
+		if len(fields) != 3 {
+			imp.issue(lineNo, raw, "unrecognized rule format")
+			continue
+		}
+
+		to, actionField, from := fields[0], fields[1], fields[2]
+
+		actionParts := strings.Fields(actionField)
+
+		action, ok := parseImportedAction(actionParts[0])
+		if !ok {
+			if strings.EqualFold(actionParts[0], "LIMIT") {
+				action = FirewallActionAccept
+				imp.issue(lineNo, raw, "LIMIT was imported as ACCEPT without rate limiting")
+			} else {
+				imp.issue(lineNo, raw, "action %s is not supported", actionParts[0])
+				continue
+			}
+		}
+
+		direction := FirewallDirectionInbound
+		if len(actionParts) > 1 && strings.EqualFold(actionParts[1], "OUT") {
+			direction = FirewallDirectionOutbound
+		}
+
+		if strings.Contains(to, " on ") || strings.Contains(from, " on ") {
+			imp.issue(lineNo, raw, "interface matches are not supported")
+			continue
+		}
+
+		family := 4
+		if strings.Contains(to, "(v6)") || strings.Contains(from, "(v6)") {
+			family = 6
+		}
+
+		to = strings.TrimSpace(strings.ReplaceAll(to, "(v6)", ""))
+		from = strings.TrimSpace(strings.ReplaceAll(from, "(v6)", ""))
+
+		// The "To" column describes the local side for inbound rules and the remote
+		// side for outbound rules, while "From" is the opposite.
+		local, remoteSide := to, from
+		if direction == FirewallDirectionOutbound {
+			local, remoteSide = from, to
+		}
+
+		remoteAddr, remotePort := splitUFWEndpoint(remoteSide)
+		localAddr, localPort := splitUFWEndpoint(local)
+
+		if localAddr != "" {
+			imp.issue(lineNo, raw, "matching the local address is not supported")
+			continue
+		}
+
+		portSpec := remotePort
+		if direction == FirewallDirectionInbound {
+			portSpec = localPort
+
+			if remotePort != "" {
+				imp.issue(lineNo, raw, "source port matches are not supported")
+				continue
+			}
+		} else if localPort != "" {
+			imp.issue(lineNo, raw, "source port matches are not supported")
+			continue
+		}
+
+		protocol := ""
+		if p, proto, found := strings.Cut(portSpec, "/"); found {
+			portSpec, protocol = p, proto
+		}
+
+		if portSpec != "" && !ufwPortsRegex.MatchString(portSpec) {
+			imp.issue(lineNo, raw, "application profile %s is not supported", portSpec)
+			continue
+		}
+
+		ports, err := parseImportedPorts(portSpec)
+		if err != nil {
+			imp.issue(lineNo, raw, "%s", err)
+			continue
+		}
+
+		var remote []netip.Prefix
+
+		if remoteAddr != "" {
+			prefix, err := ParseFirewallAddress(remoteAddr)
+			if err != nil {
+				imp.issue(lineNo, raw, "%s", err)
+				continue
+			}
+
+			remote = append(remote, prefix)
+		}
+
+		protocols := []NetworkProtocol{TCP, UDP}
+		if protocol != "" {
+			parsed, ok := parseImportedProtocol(protocol)
+			if !ok {
+				imp.issue(lineNo, raw, "protocol %s is not supported", protocol)
+				continue
+			}
+
+			protocols = []NetworkProtocol{parsed}
+		} else if len(ports) == 0 {
+			protocols = append(protocols, ICMP)
+		}
+
+		imp.add(direction, action, protocols, ports, remote, []int{family}, comment)
+	}
+
+	if imp.result.Rules.InboundPolicy == "" || imp.result.Rules.OutboundPolicy == "" {
+		imp.issue(0, "", "default policies are unknown; use the output of `ufw status verbose`")
+	}
+
+	return imp.finish()
+}
+
+// splitUFWEndpoint splits a ufw "To" or "From" column into an address and a port spec
+func splitUFWEndpoint(s string) (string, string) {
+	fields := strings.Fields(s)
+
+	address, port := "", ""
+
+	for _, f := range fields {
+		switch {
+		case strings.EqualFold(f, "Anywhere"):
+		case strings.EqualFold(f, "port"):
+		case strings.ContainsAny(f, ".") || strings.Count(f, ":") > 1:
+			address = f
+		default:
+			port = f
+		}
+	}
+
+	return address, port
+}
+
+// splitShellFields splits a line into fields, honouring double quotes
+func splitShellFields(line string) []string {
+	fields := make([]string, 0)
+
+	var (
+		current strings.Builder
+		quoted  bool
+		started bool
+	)
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			started = true
+		case r == ' ' && !quoted:
+			if started {
+				fields = append(fields, current.String())
+				current.Reset()
+
+				started = false
+			}
+		default:
+			current.WriteRune(r)
+
+			started = true
+		}
+	}
+
+	if started {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// splitNFTablesTokens splits an nftables rule into tokens, keeping anonymous sets
+// such as "{ 80, 443 }" and quoted strings as single tokens.
+func splitNFTablesTokens(line string) []string {
+	tokens := make([]string, 0)
+
+	var current strings.Builder
+
+	depth, quoted := 0, false
+
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case r == '{' && !quoted:
+			depth++
+			current.WriteRune(r)
+		case r == '}' && !quoted:
+			depth--
+			current.WriteRune(r)
+		case (r == ' ' || r == '\t') && depth == 0 && !quoted:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return tokens
+}
+
+// splitNFTablesSet expands an anonymous set such as "{ 80, 443 }" into its elements
+func splitNFTablesSet(token string) []string {
+	token = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(token, "{"), "}"))
+
+	elements := make([]string, 0)
+
+	for _, e := range strings.Split(token, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			elements = append(elements, e)
+		}
+	}
+
+	return elements
+}
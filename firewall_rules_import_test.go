@@ -0,0 +1,278 @@
+package linodego
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestImportIPTablesSave(t *testing.T) {
+	data := `# Generated by iptables-save
+*nat
+:PREROUTING ACCEPT [0:0]
+-A PREROUTING -p tcp --dport 8080 -j REDIRECT --to-ports 80
+COMMIT
+*filter
+:INPUT DROP [0:0]
+:FORWARD ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+-A INPUT -m state --state RELATED,ESTABLISHED -j ACCEPT
+-A INPUT -i lo -j ACCEPT
+-A INPUT -s 10.0.0.0/8 -p tcp -m tcp --dport 22 -m comment --comment "ssh from lan" -j ACCEPT
+-A INPUT -p tcp -m multiport --dports 80,443,8000:8100 -j ACCEPT
+-A INPUT -s 203.0.113.0/24 -j REJECT --reject-with icmp-port-unreachable
+-A INPUT -p tcp -j LOG
+COMMIT
+`
+
+	result := ImportIPTablesSave(data, false)
+
+	if result.Rules.InboundPolicy != "DROP" || result.Rules.OutboundPolicy != "ACCEPT" {
+		t.Errorf("unexpected policies: %s/%s", result.Rules.InboundPolicy, result.Rules.OutboundPolicy)
+	}
+
+	if len(result.Rules.Inbound) != 5 {
+		t.Fatalf("expected 5 inbound rules, got %d: %+v", len(result.Rules.Inbound), result.Rules.Inbound)
+	}
+
+	ssh := result.Rules.Inbound[0]
+	if ssh.Label != "ssh-from-lan" || ssh.Ports != "22" || !reflect.DeepEqual(*ssh.Addresses.IPv4, []string{"10.0.0.0/8"}) {
+		t.Errorf("unexpected ssh rule: %+v", ssh)
+	}
+
+	if web := result.Rules.Inbound[1]; web.Ports != "80, 443, 8000-8100" || web.Addresses.IPv4 == nil || (*web.Addresses.IPv4)[0] != "0.0.0.0/0" {
+		t.Errorf("unexpected web rule: %+v", web)
+	}
+
+	if drop := result.Rules.Inbound[2]; drop.Action != "DROP" || drop.Protocol != TCP {
+		t.Errorf("unexpected reject rule: %+v", drop)
+	}
+
+	// state, lo, REJECT approximation and LOG target
+	if len(result.Issues) != 4 {
+		t.Errorf("expected 4 issues, got %v", result.Issues)
+	}
+}
+
+func TestImportNFTables(t *testing.T) {
+	data := `table inet filter {
+	chain input {
+		type filter hook input priority 0; policy drop;
+		ct state established,related accept
+		tcp dport { 80, 443 } accept
+		ip saddr { 10.0.0.0/8, 192.168.0.0/16 } tcp dport 5432 counter accept comment "postgres"
+		iifname "eth1" accept
+	}
+}
+`
+
+	result := ImportNFTables(data)
+
+	if result.Rules.InboundPolicy != "DROP" {
+		t.Errorf("unexpected inbound policy: %s", result.Rules.InboundPolicy)
+	}
+
+	if len(result.Rules.Inbound) != 2 || len(result.Issues) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	web := result.Rules.Inbound[0]
+	if web.Ports != "80, 443" || web.Addresses.IPv4 == nil || web.Addresses.IPv6 == nil {
+		t.Errorf("unexpected web rule: %+v", web)
+	}
+
+	if db := result.Rules.Inbound[1]; db.Label != "postgres" || len(*db.Addresses.IPv4) != 2 {
+		t.Errorf("unexpected database rule: %+v", db)
+	}
+}
+
+func TestImportNFTables_Reject(t *testing.T) {
+	data := `table inet filter {
+	chain input {
+		type filter hook input priority 0; policy accept;
+		ip saddr 203.0.113.0/24 reject with icmp type port-unreachable
+		ip6 saddr 2001:db8::/32 reject with icmpv6 type admin-prohibited
+		tcp dport 25 reject with tcp reset
+		udp dport 53 reject with icmpx port-unreachable
+		ip protocol
+	}
+}
+`
+
+	result := ImportNFTables(data)
+
+	// Rules without a protocol expand to TCP, UDP and ICMP
+	if len(result.Rules.Inbound) != 8 {
+		t.Fatalf("expected 8 inbound rules, got %+v (issues %v)", result.Rules.Inbound, result.Issues)
+	}
+
+	for _, rule := range result.Rules.Inbound {
+		if rule.Action != FirewallActionDrop {
+			t.Errorf("expected reject to be imported as DROP: %+v", rule)
+		}
+	}
+
+	// four reject approximations and the truncated protocol match
+	if len(result.Issues) != 5 || result.Issues[4].Reason != "missing protocol" {
+		t.Errorf("unexpected issues: %v", result.Issues)
+	}
+}
+
+func TestImportIPTablesSave_BareChain(t *testing.T) {
+	result := ImportIPTablesSave("*filter\n:\n:INPUT DROP [0:0]\nCOMMIT\n", false)
+
+	if result.Rules.InboundPolicy != "DROP" || len(result.Issues) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestImportUFWStatus(t *testing.T) {
+	data := `Status: active
+Logging: on (low)
+Default: deny (incoming), allow (outgoing), disabled (routed)
+New profiles: skip
+
+To                         Action      From
+--                         ------      ----
+22/tcp                     LIMIT IN    Anywhere
+80,443/tcp                 ALLOW IN    Anywhere                   # web
+5432                       ALLOW IN    10.0.0.0/8
+OpenSSH                    ALLOW IN    Anywhere
+22/tcp (v6)                LIMIT IN    Anywhere (v6)
+53/udp                     ALLOW OUT   Anywhere
+`
+
+	result := ImportUFWStatus(data)
+
+	if result.Rules.InboundPolicy != "DROP" || result.Rules.OutboundPolicy != "ACCEPT" {
+		t.Errorf("unexpected policies: %s/%s", result.Rules.InboundPolicy, result.Rules.OutboundPolicy)
+	}
+
+	// 5432 without a protocol expands to TCP and UDP
+	if len(result.Rules.Inbound) != 5 || len(result.Rules.Outbound) != 1 {
+		t.Fatalf("unexpected rules: %+v", result.Rules)
+	}
+
+	if v6 := result.Rules.Inbound[4]; v6.Addresses.IPv6 == nil || (*v6.Addresses.IPv6)[0] != "::/0" {
+		t.Errorf("unexpected v6 rule: %+v", v6)
+	}
+
+	if out := result.Rules.Outbound[0]; out.Protocol != UDP || out.Ports != "53" {
+		t.Errorf("unexpected outbound rule: %+v", out)
+	}
+
+	// two LIMIT approximations and the application profile
+	if len(result.Issues) != 3 {
+		t.Errorf("expected 3 issues, got %v", result.Issues)
+	}
+
+	// Without verbose output the default policies are unknown
+	result = ImportUFWStatus(`Status: active
+
+To                         Action      From
+--                         ------      ----
+80/tcp                     ALLOW IN    Anywhere                   # café web tier with a long comment for the description
+`)
+
+	if result.Rules.InboundPolicy != "" || result.Rules.OutboundPolicy != "" || len(result.Issues) != 1 {
+		t.Errorf("expected unknown default policies, got %+v", result)
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	if s := truncateString("café", 4); s != "caf" {
+		t.Errorf("expected the multi-byte rune to be dropped, got %q", s)
+	}
+
+	if s := truncateString("café", 5); s != "café" {
+		t.Errorf("unexpected truncation: %q", s)
+	}
+}
+
+func TestExportFirewallRules_RoundTrip(t *testing.T) {
+	web := FirewallTemplateWebServer()
+
+	ssh, err := FirewallTemplateBastionSSH("192.0.2.10", "2001:db8::10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := MergeFirewallRuleSets(web, ssh)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rules.Inbound) != 3 || rules.InboundPolicy != "DROP" {
+		t.Fatalf("unexpected merged rules: %+v", rules)
+	}
+
+	nft, err := ExportNFTables(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported := ImportNFTables(nft)
+
+	diff, err := DiffFirewallRuleSets(rules, imported.Rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Descriptions are not exported, so only label and description updates are expected
+	for _, change := range diff.Changes {
+		if change.Type != FirewallRuleUpdated {
+			t.Errorf("expected nftables round trip to preserve rules, got %+v\n%s", diff.Changes, nft)
+			break
+		}
+	}
+
+	ipt, err := ExportIPTablesSave(rules, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v4 := ImportIPTablesSave(ipt, false); len(v4.Rules.Inbound) != 3 {
+		t.Errorf("expected 3 IPv4 rules, got %+v\n%s", v4.Rules.Inbound, ipt)
+	}
+
+	commands, err := ExportUFWCommands(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if commands[0] != "ufw default deny incoming" || commands[2] != "ufw allow in proto tcp from any to any port 80,443 comment 'allow-http'" {
+		t.Errorf("unexpected ufw commands: %v", commands)
+	}
+}
+
+func TestExportUFWCommands_AnywhereAndQuoting(t *testing.T) {
+	rules := FirewallRuleSet{
+		InboundPolicy:  "DROP",
+		OutboundPolicy: "ACCEPT",
+		Inbound: []FirewallRule{{
+			Action:   "ACCEPT",
+			Label:    "bob's-web",
+			Protocol: TCP,
+			Ports:    "80",
+			Addresses: NetworkAddresses{
+				IPv4: &[]string{"0.0.0.0/0"},
+				IPv6: &[]string{"::/0"},
+			},
+		}},
+	}
+
+	commands, err := ExportUFWCommands(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The IPv4 and IPv6 anywhere prefixes produce a single command
+	expected := []string{
+		"ufw default deny incoming",
+		"ufw default allow outgoing",
+		`ufw allow in proto tcp from any to any port 80 comment 'bob'\''s-web'`,
+	}
+
+	if !reflect.DeepEqual(commands, expected) {
+		t.Errorf("unexpected ufw commands: %q", commands)
+	}
+}
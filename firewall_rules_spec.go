@@ -0,0 +1,620 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Firewall rule limits enforced by the Linode API
+const (
+	FirewallMaxRules                 = 25
+	FirewallMaxAddressesPerRule      = 255
+	FirewallMaxPortPiecesPerRule     = 15
+	FirewallRuleLabelMinLength       = 3
+	FirewallRuleLabelMaxLength       = 32
+	FirewallRuleDescriptionMaxLength = 100
+)
+
+// FirewallRuleAction constants are the actions a FirewallRule or policy can take
+const (
+	FirewallActionAccept = "ACCEPT"
+	FirewallActionDrop   = "DROP"
+)
+
+var firewallRuleLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9\-_.]*[a-zA-Z0-9])?$`)
+
+// FirewallPortRange is an inclusive range of ports
+type FirewallPortRange struct {
+	From int
+	To   int
+}
+
+// FirewallRuleSpec is a parsed FirewallRule with typed ports and addresses
+type FirewallRuleSpec struct {
+	Action      string
+	Label       string
+	Description string
+	Protocol    NetworkProtocol
+
+	// Ports is nil if the rule applies to all ports
+	Ports []FirewallPortRange
+
+	Addresses []netip.Prefix
+}
+
+// FirewallRuleSetSpec is a parsed FirewallRuleSet
+type FirewallRuleSetSpec struct {
+	Inbound        []FirewallRuleSpec
+	InboundPolicy  string
+	Outbound       []FirewallRuleSpec
+	OutboundPolicy string
+}
+
+// FirewallRuleChangeType is the kind of difference between two rule sets
+type FirewallRuleChangeType string
+
+// FirewallRuleChangeType constants are the kinds of rule changes found by DiffFirewallRuleSets
+const (
+	FirewallRuleAdded   FirewallRuleChangeType = "added"
+	FirewallRuleRemoved FirewallRuleChangeType = "removed"
+	FirewallRuleUpdated FirewallRuleChangeType = "updated"
+)
+
+// FirewallRuleChange is a single semantic difference between two rule sets
+type FirewallRuleChange struct {
+	Type      FirewallRuleChangeType
+	Direction string
+	Current   *FirewallRuleSpec
+	Desired   *FirewallRuleSpec
+}
+
+// FirewallRuleSetDiff is the semantic difference between a current and desired rule set
+type FirewallRuleSetDiff struct {
+	InboundPolicyChanged  bool
+	OutboundPolicyChanged bool
+	InboundReordered      bool
+	OutboundReordered     bool
+	Changes               []FirewallRuleChange
+
+	// Desired is the normalized desired rule set to pass to UpdateFirewallRules
+	Desired FirewallRuleSet
+}
+
+// String returns the port range in the format used by FirewallRule.Ports
+func (r FirewallPortRange) String() string {
+	if r.From == r.To {
+		return strconv.Itoa(r.From)
+	}
+
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+// Contains returns true if port is within the range
+func (r FirewallPortRange) Contains(port int) bool {
+	return port >= r.From && port <= r.To
+}
+
+// ParseFirewallPorts parses a FirewallRule.Ports string such as "22, 80, 1000-2000".
+// An empty string means all ports and is returned as nil.
+func ParseFirewallPorts(ports string) ([]FirewallPortRange, error) {
+	ports = strings.TrimSpace(ports)
+	if ports == "" {
+		return nil, nil
+	}
+
+	result := make([]FirewallPortRange, 0)
+
+	for _, piece := range strings.Split(ports, ",") {
+		piece = strings.TrimSpace(piece)
+
+		from, to, isRange := strings.Cut(piece, "-")
+		if !isRange {
+			to = from
+		}
+
+		start, err := parseFirewallPort(from)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := parseFirewallPort(to)
+		if err != nil {
+			return nil, err
+		}
+
+		if start > end {
+			return nil, fmt.Errorf("invalid port range %s", piece)
+		}
+
+		result = append(result, FirewallPortRange{From: start, To: end})
+	}
+
+	return result, nil
+}
+
+// FormatFirewallPorts formats port ranges as a FirewallRule.Ports string
+func FormatFirewallPorts(ranges []FirewallPortRange) string {
+	pieces := make([]string, len(ranges))
+	for i, r := range ranges {
+		pieces[i] = r.String()
+	}
+
+	return strings.Join(pieces, ", ")
+}
+
+func parseFirewallPort(s string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", s)
+	}
+
+	return port, nil
+}
+
+// ParseFirewallAddress parses an IPv4 or IPv6 address or CIDR into a masked prefix.
+// A bare address is treated as a single-host prefix.
+func ParseFirewallAddress(address string) (netip.Prefix, error) {
+	address = strings.TrimSpace(address)
+
+	if strings.Contains(address, "/") {
+		prefix, err := netip.ParsePrefix(address)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid address %q: %w", address, err)
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid address %q: %w", address, err)
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// ParseFirewallRule parses the ports and addresses of a FirewallRule
+func ParseFirewallRule(rule FirewallRule) (FirewallRuleSpec, error) {
+	spec := FirewallRuleSpec{
+		Action:      rule.Action,
+		Label:       rule.Label,
+		Description: rule.Description,
+		Protocol:    rule.Protocol,
+	}
+
+	var err error
+
+	if spec.Ports, err = ParseFirewallPorts(rule.Ports); err != nil {
+		return spec, fmt.Errorf("rule %s: %w", rule.Label, err)
+	}
+
+	for _, family := range []*[]string{rule.Addresses.IPv4, rule.Addresses.IPv6} {
+		if family == nil {
+			continue
+		}
+
+		for _, address := range *family {
+			prefix, err := ParseFirewallAddress(address)
+			if err != nil {
+				return spec, fmt.Errorf("rule %s: %w", rule.Label, err)
+			}
+
+			spec.Addresses = append(spec.Addresses, prefix)
+		}
+	}
+
+	return spec, nil
+}
+
+// Rule converts the spec back into a FirewallRule
+func (s FirewallRuleSpec) Rule() FirewallRule {
+	rule := FirewallRule{
+		Action:      s.Action,
+		Label:       s.Label,
+		Description: s.Description,
+		Ports:       FormatFirewallPorts(s.Ports),
+		Protocol:    s.Protocol,
+	}
+
+	var ipv4, ipv6 []string
+
+	for _, prefix := range s.Addresses {
+		if prefix.Addr().Is4() {
+			ipv4 = append(ipv4, prefix.String())
+		} else {
+			ipv6 = append(ipv6, prefix.String())
+		}
+	}
+
+	if len(ipv4) > 0 {
+		rule.Addresses.IPv4 = &ipv4
+	}
+
+	if len(ipv6) > 0 {
+		rule.Addresses.IPv6 = &ipv6
+	}
+
+	return rule
+}
+
+// Normalize returns a copy of the spec with an uppercase action and protocol,
+// sorted and merged port ranges, and sorted addresses with duplicates and
+// prefixes covered by other prefixes removed.
+func (s FirewallRuleSpec) Normalize() FirewallRuleSpec {
+	s.Action = strings.ToUpper(s.Action)
+	s.Protocol = NetworkProtocol(strings.ToUpper(string(s.Protocol)))
+	s.Ports = normalizeFirewallPorts(s.Ports)
+	s.Addresses = normalizeFirewallAddresses(s.Addresses)
+
+	return s
+}
+
+// Key returns a string identifying the traffic matched by the rule and its action.
+// Two normalized rules with the same key are semantically identical.
+func (s FirewallRuleSpec) Key() string {
+	addresses := make([]string, len(s.Addresses))
+	for i, prefix := range s.Addresses {
+		addresses[i] = prefix.String()
+	}
+
+	return strings.Join([]string{
+		s.Action,
+		string(s.Protocol),
+		FormatFirewallPorts(s.Ports),
+		strings.Join(addresses, ","),
+	}, "|")
+}
+
+// Validate checks the rule against the limits enforced by the Linode API.
+// Labels are optional, so only non-empty labels are checked.
+func (s FirewallRuleSpec) Validate() error {
+	if s.Label != "" {
+		if len(s.Label) < FirewallRuleLabelMinLength || len(s.Label) > FirewallRuleLabelMaxLength {
+			return fmt.Errorf("rule label %q must be between %d and %d characters",
+				s.Label, FirewallRuleLabelMinLength, FirewallRuleLabelMaxLength)
+		}
+
+		if !firewallRuleLabelRegex.MatchString(s.Label) {
+			return fmt.Errorf("rule label %q contains invalid characters", s.Label)
+		}
+	}
+
+	if len(s.Description) > FirewallRuleDescriptionMaxLength {
+		return fmt.Errorf("rule %s description exceeds %d characters", s.Label, FirewallRuleDescriptionMaxLength)
+	}
+
+	action := strings.ToUpper(s.Action)
+	if action != FirewallActionAccept && action != FirewallActionDrop {
+		return fmt.Errorf("rule %s has invalid action %q", s.Label, s.Action)
+	}
+
+	switch NetworkProtocol(strings.ToUpper(string(s.Protocol))) {
+	case TCP, UDP:
+	case ICMP, IPENCAP:
+		if len(s.Ports) > 0 {
+			return fmt.Errorf("rule %s cannot specify ports for protocol %s", s.Label, s.Protocol)
+		}
+	default:
+		return fmt.Errorf("rule %s has invalid protocol %q", s.Label, s.Protocol)
+	}
+
+	pieces := 0
+	for _, r := range s.Ports {
+		pieces++
+		if r.From != r.To {
+			pieces++
+		}
+	}
+
+	if pieces > FirewallMaxPortPiecesPerRule {
+		return fmt.Errorf("rule %s specifies %d port pieces, exceeding the limit of %d",
+			s.Label, pieces, FirewallMaxPortPiecesPerRule)
+	}
+
+	if len(s.Addresses) == 0 {
+		return fmt.Errorf("rule %s must specify at least one address", s.Label)
+	}
+
+	if len(s.Addresses) > FirewallMaxAddressesPerRule {
+		return fmt.Errorf("rule %s specifies %d addresses, exceeding the limit of %d",
+			s.Label, len(s.Addresses), FirewallMaxAddressesPerRule)
+	}
+
+	return nil
+}
+
+// ParseFirewallRuleSet parses every rule of a FirewallRuleSet
+func ParseFirewallRuleSet(rules FirewallRuleSet) (*FirewallRuleSetSpec, error) {
+	spec := &FirewallRuleSetSpec{
+		InboundPolicy:  rules.InboundPolicy,
+		OutboundPolicy: rules.OutboundPolicy,
+	}
+
+	for _, rule := range rules.Inbound {
+		parsed, err := ParseFirewallRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("inbound %w", err)
+		}
+
+		spec.Inbound = append(spec.Inbound, parsed)
+	}
+
+	for _, rule := range rules.Outbound {
+		parsed, err := ParseFirewallRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("outbound %w", err)
+		}
+
+		spec.Outbound = append(spec.Outbound, parsed)
+	}
+
+	return spec, nil
+}
+
+// Normalize returns a copy of the rule set with every rule normalized and
+// semantically duplicate rules removed, keeping the first occurrence.
+func (s FirewallRuleSetSpec) Normalize() FirewallRuleSetSpec {
+	s.InboundPolicy = strings.ToUpper(s.InboundPolicy)
+	s.OutboundPolicy = strings.ToUpper(s.OutboundPolicy)
+	s.Inbound = normalizeFirewallRules(s.Inbound)
+	s.Outbound = normalizeFirewallRules(s.Outbound)
+
+	return s
+}
+
+// RuleSet converts the spec back into a FirewallRuleSet
+func (s FirewallRuleSetSpec) RuleSet() FirewallRuleSet {
+	result := FirewallRuleSet{
+		Inbound:        make([]FirewallRule, len(s.Inbound)),
+		InboundPolicy:  s.InboundPolicy,
+		Outbound:       make([]FirewallRule, len(s.Outbound)),
+		OutboundPolicy: s.OutboundPolicy,
+	}
+
+	for i, rule := range s.Inbound {
+		result.Inbound[i] = rule.Rule()
+	}
+
+	for i, rule := range s.Outbound {
+		result.Outbound[i] = rule.Rule()
+	}
+
+	return result
+}
+
+// Validate checks the rule set and every rule against the limits enforced by the Linode API
+func (s FirewallRuleSetSpec) Validate() error {
+	for _, policy := range []string{s.InboundPolicy, s.OutboundPolicy} {
+		if p := strings.ToUpper(policy); p != FirewallActionAccept && p != FirewallActionDrop {
+			return fmt.Errorf("invalid policy %q", policy)
+		}
+	}
+
+	if total := len(s.Inbound) + len(s.Outbound); total > FirewallMaxRules {
+		return fmt.Errorf("rule set contains %d rules, exceeding the limit of %d", total, FirewallMaxRules)
+	}
+
+	for _, rule := range append(append([]FirewallRuleSpec{}, s.Inbound...), s.Outbound...) {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DiffFirewallRuleSets computes the semantic difference between the current and desired
+// rule sets. Rules are compared by the traffic they match and their action; label and
+// description changes are reported as updates.
+func DiffFirewallRuleSets(current, desired FirewallRuleSet) (*FirewallRuleSetDiff, error) {
+	currentSpec, err := ParseFirewallRuleSet(current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse current rules: %w", err)
+	}
+
+	desiredSpec, err := ParseFirewallRuleSet(desired)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse desired rules: %w", err)
+	}
+
+	c := currentSpec.Normalize()
+	d := desiredSpec.Normalize()
+
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	diff := &FirewallRuleSetDiff{
+		InboundPolicyChanged:  c.InboundPolicy != d.InboundPolicy,
+		OutboundPolicyChanged: c.OutboundPolicy != d.OutboundPolicy,
+		Desired:               d.RuleSet(),
+	}
+
+	var changes []FirewallRuleChange
+
+	changes, diff.InboundReordered = diffFirewallRules("inbound", c.Inbound, d.Inbound)
+	diff.Changes = append(diff.Changes, changes...)
+
+	changes, diff.OutboundReordered = diffFirewallRules("outbound", c.Outbound, d.Outbound)
+	diff.Changes = append(diff.Changes, changes...)
+
+	return diff, nil
+}
+
+// Empty returns true if the rule sets are semantically identical
+func (d *FirewallRuleSetDiff) Empty() bool {
+	return !d.InboundPolicyChanged && !d.OutboundPolicyChanged &&
+		!d.InboundReordered && !d.OutboundReordered && len(d.Changes) == 0
+}
+
+// SyncFirewallRules updates the rules of the Firewall with the provided ID to the desired
+// rule set if they differ semantically. The computed diff is returned; when dryRun is true
+// no update is made.
+func (c *Client) SyncFirewallRules(ctx context.Context, firewallID int, desired FirewallRuleSet, dryRun bool) (*FirewallRuleSetDiff, error) {
+	current, err := c.GetFirewallRules(ctx, firewallID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := DiffFirewallRuleSets(*current, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	if diff.Empty() || dryRun {
+		return diff, nil
+	}
+
+	if _, err := c.UpdateFirewallRules(ctx, firewallID, diff.Desired); err != nil {
+		return diff, err
+	}
+
+	return diff, nil
+}
+
+func diffFirewallRules(direction string, current, desired []FirewallRuleSpec) ([]FirewallRuleChange, bool) {
+	changes := make([]FirewallRuleChange, 0)
+
+	currentByKey := make(map[string]int, len(current))
+	for i, rule := range current {
+		currentByKey[rule.Key()] = i
+	}
+
+	desiredByKey := make(map[string]int, len(desired))
+	for i, rule := range desired {
+		desiredByKey[rule.Key()] = i
+	}
+
+	for i := range current {
+		if _, ok := desiredByKey[current[i].Key()]; !ok {
+			changes = append(changes, FirewallRuleChange{
+				Type: FirewallRuleRemoved, Direction: direction, Current: &current[i],
+			})
+		}
+	}
+
+	commonOrder := make([]int, 0)
+
+	for i := range desired {
+		j, ok := currentByKey[desired[i].Key()]
+		if !ok {
+			changes = append(changes, FirewallRuleChange{
+				Type: FirewallRuleAdded, Direction: direction, Desired: &desired[i],
+			})
+
+			continue
+		}
+
+		commonOrder = append(commonOrder, j)
+
+		if current[j].Label != desired[i].Label || current[j].Description != desired[i].Description {
+			changes = append(changes, FirewallRuleChange{
+				Type: FirewallRuleUpdated, Direction: direction, Current: &current[j], Desired: &desired[i],
+			})
+		}
+	}
+
+	return changes, !sort.IntsAreSorted(commonOrder)
+}
+
+func normalizeFirewallRules(rules []FirewallRuleSpec) []FirewallRuleSpec {
+	result := make([]FirewallRuleSpec, 0, len(rules))
+	seen := make(map[string]bool, len(rules))
+
+	for _, rule := range rules {
+		rule = rule.Normalize()
+
+		if key := rule.Key(); !seen[key] {
+			seen[key] = true
+			result = append(result, rule)
+		}
+	}
+
+	return result
+}
+
+func normalizeFirewallPorts(ports []FirewallPortRange) []FirewallPortRange {
+	if len(ports) == 0 {
+		return nil
+	}
+
+	sorted := append([]FirewallPortRange{}, ports...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].From < sorted[j].From
+	})
+
+	result := []FirewallPortRange{sorted[0]}
+
+	for _, r := range sorted[1:] {
+		last := &result[len(result)-1]
+
+		if r.From <= last.To+1 {
+			if r.To > last.To {
+				last.To = r.To
+			}
+
+			continue
+		}
+
+		result = append(result, r)
+	}
+
+	if len(result) == 1 && result[0].From == 1 && result[0].To == 65535 {
+		return nil
+	}
+
+	return result
+}
+
+func normalizeFirewallAddresses(addresses []netip.Prefix) []netip.Prefix {
+	masked := make([]netip.Prefix, len(addresses))
+	for i, prefix := range addresses {
+		masked[i] = prefix.Masked()
+	}
+
+	// Broader prefixes sort first so covered prefixes can be dropped in one pass
+	sort.Slice(masked, func(i, j int) bool {
+		a, b := masked[i], masked[j]
+		if a.Addr().BitLen() != b.Addr().BitLen() {
+			return a.Addr().BitLen() < b.Addr().BitLen()
+		}
+
+		if a.Bits() != b.Bits() {
+			return a.Bits() < b.Bits()
+		}
+
+		return a.Addr().Less(b.Addr())
+	})
+
+	result := make([]netip.Prefix, 0, len(masked))
+
+	for _, prefix := range masked {
+		covered := false
+
+		for _, kept := range result {
+			if kept.Bits() <= prefix.Bits() && kept.Contains(prefix.Addr()) {
+				covered = true
+				break
+			}
+		}
+
+		if !covered {
+			result = append(result, prefix)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Addr() != result[j].Addr() {
+			return result[i].Addr().Less(result[j].Addr())
+		}
+
+		return result[i].Bits() < result[j].Bits()
+	})
+
+	return result
+}
@@ -0,0 +1,124 @@
+package linodego
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseFirewallPorts(t *testing.T) {
+	ports, err := ParseFirewallPorts("443, 80,1000-2000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []FirewallPortRange{{443, 443}, {80, 80}, {1000, 2000}}
+	if !reflect.DeepEqual(ports, expected) {
+		t.Fatalf("unexpected ports: %v", ports)
+	}
+
+	for _, invalid := range []string{"0", "70000", "20-10", "http"} {
+		if _, err := ParseFirewallPorts(invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
+
+func TestFirewallRuleSpec_Normalize(t *testing.T) {
+	spec, err := ParseFirewallRule(FirewallRule{
+		Action:   "accept",
+		Label:    "web",
+		Ports:    "443, 80, 81-90, 85",
+		Protocol: "tcp",
+		Addresses: NetworkAddresses{
+			IPv4: &[]string{"10.0.0.5", "10.0.0.0/8", "192.0.2.1/24"},
+			IPv6: &[]string{"2001:db8::/32", "2001:db8::1"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rule := spec.Normalize().Rule()
+
+	if rule.Action != "ACCEPT" || rule.Protocol != TCP {
+		t.Errorf("unexpected action/protocol: %s/%s", rule.Action, rule.Protocol)
+	}
+
+	if rule.Ports != "80-90, 443" {
+		t.Errorf("unexpected ports: %s", rule.Ports)
+	}
+
+	if !reflect.DeepEqual(*rule.Addresses.IPv4, []string{"10.0.0.0/8", "192.0.2.0/24"}) {
+		t.Errorf("unexpected ipv4 addresses: %v", *rule.Addresses.IPv4)
+	}
+
+	if !reflect.DeepEqual(*rule.Addresses.IPv6, []string{"2001:db8::/32"}) {
+		t.Errorf("unexpected ipv6 addresses: %v", *rule.Addresses.IPv6)
+	}
+}
+
+func TestDiffFirewallRuleSets(t *testing.T) {
+	ssh := FirewallRule{
+		Action: "ACCEPT", Label: "ssh", Ports: "22", Protocol: TCP,
+		Addresses: NetworkAddresses{IPv4: &[]string{"0.0.0.0/0"}},
+	}
+	web := FirewallRule{
+		Action: "ACCEPT", Label: "web", Ports: "80,443", Protocol: TCP,
+		Addresses: NetworkAddresses{IPv4: &[]string{"0.0.0.0/0"}},
+	}
+
+	current := FirewallRuleSet{
+		Inbound:        []FirewallRule{ssh},
+		InboundPolicy:  "DROP",
+		OutboundPolicy: "ACCEPT",
+	}
+
+	renamed := ssh
+	renamed.Label = "ssh-all"
+	renamed.Ports = "22, 22"
+
+	desired := FirewallRuleSet{
+		Inbound:        []FirewallRule{renamed, web, web},
+		InboundPolicy:  "drop",
+		OutboundPolicy: "ACCEPT",
+	}
+
+	diff, err := DiffFirewallRuleSets(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff.InboundPolicyChanged || diff.InboundReordered {
+		t.Error("expected policy and order to be unchanged")
+	}
+
+	if len(diff.Changes) != 2 ||
+		diff.Changes[0].Type != FirewallRuleUpdated ||
+		diff.Changes[1].Type != FirewallRuleAdded {
+		t.Fatalf("unexpected changes: %+v", diff.Changes)
+	}
+
+	if len(diff.Desired.Inbound) != 2 {
+		t.Errorf("expected duplicate rules to be removed, got %d rules", len(diff.Desired.Inbound))
+	}
+
+	if diff, _ := DiffFirewallRuleSets(current, current); !diff.Empty() {
+		t.Errorf("expected an empty diff, got %+v", diff)
+	}
+
+	// Labels are optional in the API
+	unlabeled := ssh
+	unlabeled.Label = ""
+	current.Inbound = []FirewallRule{unlabeled}
+
+	if diff, err := DiffFirewallRuleSets(current, current); err != nil || !diff.Empty() {
+		t.Errorf("expected an empty diff for unlabeled rules, got %+v, %v", diff, err)
+	}
+
+	unlabeled.Label = "x"
+	current.Inbound = []FirewallRule{unlabeled}
+
+	if _, err := DiffFirewallRuleSets(current, current); err == nil {
+		t.Error("expected an error for a label shorter than the minimum length")
+	}
+}
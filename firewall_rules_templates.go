@@ -0,0 +1,135 @@
+package linodego
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// FirewallTemplateWebServer returns a rule set that accepts HTTP and HTTPS from anywhere,
+// drops all other inbound traffic and accepts all outbound traffic
+func FirewallTemplateWebServer() FirewallRuleSet {
+	anywhere := firewallAnywhere()
+
+	return firewallTemplate(
+		FirewallRuleSpec{
+			Action:      FirewallActionAccept,
+			Label:       "allow-http",
+			Description: "Allow HTTP and HTTPS from anywhere",
+			Protocol:    TCP,
+			Ports:       []FirewallPortRange{{80, 80}, {443, 443}},
+			Addresses:   anywhere,
+		},
+		FirewallRuleSpec{
+			Action:      FirewallActionAccept,
+			Label:       "allow-icmp",
+			Description: "Allow ICMP from anywhere",
+			Protocol:    ICMP,
+			Addresses:   anywhere,
+		},
+	)
+}
+
+// FirewallTemplateBastionSSH returns a rule set that only accepts SSH from the provided
+// bastion addresses or CIDRs and drops all other inbound traffic
+func FirewallTemplateBastionSSH(bastions ...string) (FirewallRuleSet, error) {
+	addresses, err := parseFirewallTemplateAddresses(bastions)
+	if err != nil {
+		return FirewallRuleSet{}, err
+	}
+
+	return firewallTemplate(FirewallRuleSpec{
+		Action:      FirewallActionAccept,
+		Label:       "allow-ssh-bastion",
+		Description: "Allow SSH from bastion hosts",
+		Protocol:    TCP,
+		Ports:       []FirewallPortRange{{22, 22}},
+		Addresses:   addresses,
+	}), nil
+}
+
+// FirewallTemplateVPCDatabase returns a rule set that accepts connections to the database
+// port from the provided VPC subnet CIDRs and drops all other inbound traffic
+func FirewallTemplateVPCDatabase(port int, subnets ...string) (FirewallRuleSet, error) {
+	if port < 1 || port > 65535 {
+		return FirewallRuleSet{}, fmt.Errorf("invalid port %d", port)
+	}
+
+	addresses, err := parseFirewallTemplateAddresses(subnets)
+	if err != nil {
+		return FirewallRuleSet{}, err
+	}
+
+	return firewallTemplate(FirewallRuleSpec{
+		Action:      FirewallActionAccept,
+		Label:       fmt.Sprintf("allow-db-%d", port),
+		Description: "Allow database connections from VPC subnets",
+		Protocol:    TCP,
+		Ports:       []FirewallPortRange{{port, port}},
+		Addresses:   addresses,
+	}), nil
+}
+
+// MergeFirewallRuleSets combines rule sets in order, removing duplicate rules.
+// A direction's policy is DROP if any of the rule sets drops by default.
+func MergeFirewallRuleSets(sets ...FirewallRuleSet) (FirewallRuleSet, error) {
+	merged := FirewallRuleSetSpec{
+		InboundPolicy:  FirewallActionAccept,
+		OutboundPolicy: FirewallActionAccept,
+	}
+
+	for _, set := range sets {
+		spec, err := parseNormalizedFirewallRuleSet(set)
+		if err != nil {
+			return FirewallRuleSet{}, err
+		}
+
+		merged.Inbound = append(merged.Inbound, spec.Inbound...)
+		merged.Outbound = append(merged.Outbound, spec.Outbound...)
+
+		if spec.InboundPolicy == FirewallActionDrop {
+			merged.InboundPolicy = FirewallActionDrop
+		}
+
+		if spec.OutboundPolicy == FirewallActionDrop {
+			merged.OutboundPolicy = FirewallActionDrop
+		}
+	}
+
+	merged = merged.Normalize()
+	if err := merged.Validate(); err != nil {
+		return FirewallRuleSet{}, err
+	}
+
+	return merged.RuleSet(), nil
+}
+
+func firewallTemplate(inbound ...FirewallRuleSpec) FirewallRuleSet {
+	return FirewallRuleSetSpec{
+		Inbound:        inbound,
+		InboundPolicy:  FirewallActionDrop,
+		OutboundPolicy: FirewallActionAccept,
+	}.Normalize().RuleSet()
+}
+
+func firewallAnywhere() []netip.Prefix {
+	return []netip.Prefix{netip.MustParsePrefix("0.0.0.0/0"), netip.MustParsePrefix("::/0")}
+}
+
+func parseFirewallTemplateAddresses(addresses []string) ([]netip.Prefix, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("at least one address is required")
+	}
+
+	result := make([]netip.Prefix, len(addresses))
+
+	for i, address := range addresses {
+		prefix, err := ParseFirewallAddress(address)
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = prefix
+	}
+
+	return result, nil
+}
@@ -20,6 +20,12 @@ const (
 	ImageStatusAvailable     ImageStatus = "available"
 )
 
+// ImageCapability constants start with ImageCapability and include known Image capabilities
+const (
+	ImageCapabilityCloudInit        = "cloud-init"
+	ImageCapabilityDistributedSites = "distributed-sites"
+)
+
 // Image represents a deployable Image object for use with Linode Instances
 type Image struct {
 	ID           string      `json:"id"`
@@ -39,6 +45,17 @@ type Image struct {
 	EOL          *time.Time  `json:"-"`
 }
 
+// HasCapability returns true if the Image has the given capability
+func (i Image) HasCapability(capability string) bool {
+	for _, c := range i.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ImageCreateOptions fields are those accepted by CreateImage
 type ImageCreateOptions struct {
 	DiskID      int    `json:"disk_id"`
@@ -0,0 +1,506 @@
+package linodego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"regexp"
+	"sort"
+)
+
+// MaxInstanceConfigInterfaces is the maximum number of interfaces a config can have
+const MaxInstanceConfigInterfaces = 3
+
+var vlanLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9]+(-[a-zA-Z0-9]+)*$`)
+
+// InstanceConfigInterfacesBuilder builds a validated list of config interfaces
+type InstanceConfigInterfacesBuilder struct {
+	interfaces []InstanceConfigInterfaceCreateOptions
+}
+
+// InstanceConfigInterfaceAction is the kind of API call in an interface change plan
+type InstanceConfigInterfaceAction string
+
+// InstanceConfigInterfaceAction constants are the calls PlanInstanceConfigInterfaces can plan
+const (
+	InstanceConfigInterfaceAppend  InstanceConfigInterfaceAction = "append"
+	InstanceConfigInterfaceUpdate  InstanceConfigInterfaceAction = "update"
+	InstanceConfigInterfaceDelete  InstanceConfigInterfaceAction = "delete"
+	InstanceConfigInterfaceReorder InstanceConfigInterfaceAction = "reorder"
+)
+
+// InstanceConfigInterfaceChange is a single API call needed to reach the desired interfaces
+type InstanceConfigInterfaceChange struct {
+	Action InstanceConfigInterfaceAction
+
+	// InterfaceID is the interface to update or delete
+	InterfaceID int
+
+	// DesiredIndex is the position of the appended or updated interface in the desired list
+	DesiredIndex int
+
+	Create *InstanceConfigInterfaceCreateOptions
+
+	// Update is applied with Primary always sent, so a false Primary demotes the interface
+	Update *InstanceConfigInterfaceUpdateOptions
+
+	// IDs is the desired order of interfaces for a reorder; appended interfaces are 0
+	// until their IDs are known.
+	IDs []int
+}
+
+func (c InstanceConfigInterfaceChange) String() string {
+	switch c.Action {
+	case InstanceConfigInterfaceAppend:
+		return fmt.Sprintf("append %s interface at position %d", c.Create.Purpose, c.DesiredIndex)
+	case InstanceConfigInterfaceReorder:
+		return fmt.Sprintf("reorder interfaces to %v", c.IDs)
+	}
+
+	return fmt.Sprintf("%s interface %d", c.Action, c.InterfaceID)
+}
+
+// NewInstanceConfigInterfacesBuilder returns an empty InstanceConfigInterfacesBuilder
+func NewInstanceConfigInterfacesBuilder() *InstanceConfigInterfacesBuilder {
+	return &InstanceConfigInterfacesBuilder{}
+}
+
+// Public adds a public interface
+func (b *InstanceConfigInterfacesBuilder) Public() *InstanceConfigInterfacesBuilder {
+	b.interfaces = append(b.interfaces, InstanceConfigInterfaceCreateOptions{
+		Purpose: InterfacePurposePublic,
+	})
+
+	return b
+}
+
+// VLAN adds an interface attached to the VLAN with the provided label. The IPAM address is
+// optional and must be an IPv4 address in CIDR notation, such as 10.0.0.1/24.
+func (b *InstanceConfigInterfacesBuilder) VLAN(label, ipamAddress string) *InstanceConfigInterfacesBuilder {
+	b.interfaces = append(b.interfaces, InstanceConfigInterfaceCreateOptions{
+		Purpose:     InterfacePurposeVLAN,
+		Label:       label,
+		IPAMAddress: ipamAddress,
+	})
+
+	return b
+}
+
+// VPC adds an interface attached to the VPC subnet with the provided ID. The ipv4 settings
+// and routed IP ranges are optional.
+func (b *InstanceConfigInterfacesBuilder) VPC(subnetID int, ipv4 *VPCIPv4, ipRanges ...string) *InstanceConfigInterfacesBuilder {
+	opts := InstanceConfigInterfaceCreateOptions{
+		Purpose:  InterfacePurposeVPC,
+		SubnetID: Pointer(subnetID),
+		IPv4:     ipv4,
+	}
+
+	if len(ipRanges) > 0 {
+		opts.IPRanges = ipRanges
+	}
+
+	b.interfaces = append(b.interfaces, opts)
+
+	return b
+}
+
+// Primary marks the most recently added interface as the primary interface
+func (b *InstanceConfigInterfacesBuilder) Primary() *InstanceConfigInterfacesBuilder {
+	if len(b.interfaces) > 0 {
+		b.interfaces[len(b.interfaces)-1].Primary = true
+	}
+
+	return b
+}
+
+// Build validates and returns the interfaces
+func (b *InstanceConfigInterfacesBuilder) Build() ([]InstanceConfigInterfaceCreateOptions, error) {
+	if err := ValidateInstanceConfigInterfaces(b.interfaces); err != nil {
+		return nil, err
+	}
+
+	result := make([]InstanceConfigInterfaceCreateOptions, len(b.interfaces))
+	copy(result, b.interfaces)
+
+	return result, nil
+}
+
+// ValidateInstanceConfigInterfaces checks a list of interfaces against the rules enforced by
+// the Linode API: at most three interfaces, a public interface only in the first position,
+// at most one public and one VPC interface, unique VLAN labels, at most one primary interface
+// which must not be a VLAN, and well-formed IPAM addresses, VPC IPv4 settings and IP ranges.
+//
+//nolint:gocognit
+func ValidateInstanceConfigInterfaces(interfaces []InstanceConfigInterfaceCreateOptions) error {
+	var errs []error
+
+	if len(interfaces) > MaxInstanceConfigInterfaces {
+		errs = append(errs, fmt.Errorf("a config can have at most %d interfaces, got %d",
+			MaxInstanceConfigInterfaces, len(interfaces)))
+	}
+
+	counts := make(map[ConfigInterfacePurpose]int)
+	vlanLabels := make(map[string]bool)
+	primaries := 0
+
+	for i, iface := range interfaces {
+		fail := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("interface %d (%s): %s", i, iface.Purpose, fmt.Sprintf(format, args...)))
+		}
+
+		counts[iface.Purpose]++
+
+		if iface.Primary {
+			primaries++
+
+			if iface.Purpose == InterfacePurposeVLAN {
+				fail("VLAN interfaces cannot be primary")
+			}
+		}
+
+		if iface.Purpose != InterfacePurposeVLAN && (iface.Label != "" || iface.IPAMAddress != "") {
+			fail("only VLAN interfaces can have a label or IPAM address")
+		}
+
+		if iface.Purpose != InterfacePurposeVPC && (iface.SubnetID != nil || iface.IPv4 != nil || len(iface.IPRanges) > 0) {
+			fail("only VPC interfaces can have a subnet, IPv4 settings or IP ranges")
+		}
+
+		switch iface.Purpose {
+		case InterfacePurposePublic:
+			if i != 0 {
+				fail("the public interface must be the first interface")
+			}
+		case InterfacePurposeVLAN:
+			if len(iface.Label) < 1 || len(iface.Label) > 64 || !vlanLabelRegex.MatchString(iface.Label) {
+				fail("invalid VLAN label %q", iface.Label)
+			}
+
+			if vlanLabels[iface.Label] {
+				fail("VLAN %q is attached more than once", iface.Label)
+			}
+
+			vlanLabels[iface.Label] = true
+
+			if iface.IPAMAddress != "" {
+				if prefix, err := netip.ParsePrefix(iface.IPAMAddress); err != nil || !prefix.Addr().Is4() {
+					fail("IPAM address %q must be an IPv4 address in CIDR notation", iface.IPAMAddress)
+				}
+			}
+		case InterfacePurposeVPC:
+			if iface.SubnetID == nil {
+				fail("a subnet ID is required")
+			}
+
+			if err := validateVPCIPv4(iface.IPv4); err != nil {
+				fail("%s", err)
+			}
+
+			for _, r := range iface.IPRanges {
+				if prefix, err := netip.ParsePrefix(r); err != nil || !prefix.Addr().Is4() || prefix.Masked() != prefix {
+					fail("IP range %q must be an IPv4 CIDR", r)
+				}
+			}
+		default:
+			fail("unknown purpose")
+		}
+	}
+
+	if counts[InterfacePurposePublic] > 1 {
+		errs = append(errs, fmt.Errorf("a config can have at most one public interface"))
+	}
+
+	if counts[InterfacePurposeVPC] > 1 {
+		errs = append(errs, fmt.Errorf("a config can have at most one VPC interface"))
+	}
+
+	if primaries > 1 {
+		errs = append(errs, fmt.Errorf("a config can have at most one primary interface, got %d", primaries))
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateVPCIPv4(ipv4 *VPCIPv4) error {
+	if ipv4 == nil {
+		return nil
+	}
+
+	if ipv4.VPC != "" {
+		if addr, err := netip.ParseAddr(ipv4.VPC); err != nil || !addr.Is4() {
+			return fmt.Errorf("VPC address %q must be an IPv4 address", ipv4.VPC)
+		}
+	}
+
+	if ipv4.NAT1To1 != nil && *ipv4.NAT1To1 != "any" {
+		if addr, err := netip.ParseAddr(*ipv4.NAT1To1); err != nil || !addr.Is4() {
+			return fmt.Errorf("NAT 1:1 address %q must be \"any\" or an IPv4 address", *ipv4.NAT1To1)
+		}
+	}
+
+	return nil
+}
+
+// PlanInstanceConfigInterfaces computes the minimal sequence of calls that turns the current
+// interfaces into the desired interfaces. Interfaces are matched by purpose, VLAN label and
+// VPC subnet; matched interfaces are updated in place when possible and replaced otherwise.
+// Deletions are planned first, then updates, appends and finally a reorder if needed.
+//
+//nolint:gocognit
+func PlanInstanceConfigInterfaces(
+	current []InstanceConfigInterface,
+	desired []InstanceConfigInterfaceCreateOptions,
+) ([]InstanceConfigInterfaceChange, error) {
+	if err := ValidateInstanceConfigInterfaces(desired); err != nil {
+		return nil, err
+	}
+
+	matched := make([]int, len(desired))
+	used := make([]bool, len(current))
+
+	for i, want := range desired {
+		matched[i] = -1
+
+		for j, have := range current {
+			if !used[j] && instanceConfigInterfaceIdentity(have.GetCreateOptions()) == instanceConfigInterfaceIdentity(want) {
+				matched[i], used[j] = j, true
+				break
+			}
+		}
+
+		if j := matched[i]; j >= 0 && instanceConfigInterfaceNeedsReplace(current[j], want) {
+			matched[i], used[j] = -1, false
+		}
+	}
+
+	var (
+		deletes, updates, appends []InstanceConfigInterfaceChange
+		kept                      []int
+	)
+
+	for j, have := range current {
+		if !used[j] {
+			deletes = append(deletes, InstanceConfigInterfaceChange{
+				Action: InstanceConfigInterfaceDelete, InterfaceID: have.ID, DesiredIndex: -1,
+			})
+		}
+	}
+
+	for i, want := range desired {
+		j := matched[i]
+		if j < 0 {
+			create := want
+			appends = append(appends, InstanceConfigInterfaceChange{
+				Action: InstanceConfigInterfaceAppend, DesiredIndex: i, Create: &create,
+			})
+
+			continue
+		}
+
+		kept = append(kept, i)
+
+		if update, ok := instanceConfigInterfaceUpdate(current[j], want); ok {
+			updates = append(updates, InstanceConfigInterfaceChange{
+				Action: InstanceConfigInterfaceUpdate, InterfaceID: current[j].ID, DesiredIndex: i, Update: update,
+			})
+		}
+	}
+
+	// Clear the old primary before setting a new one
+	sort.SliceStable(updates, func(a, b int) bool {
+		return !updates[a].Update.Primary && updates[b].Update.Primary
+	})
+
+	changes := append(append(deletes, updates...), appends...)
+
+	// Kept interfaces retain their relative order and appended interfaces are added last
+	sort.SliceStable(kept, func(a, b int) bool {
+		return matched[kept[a]] < matched[kept[b]]
+	})
+
+	order := kept
+	for _, change := range appends {
+		order = append(order, change.DesiredIndex)
+	}
+
+	if !sort.IntsAreSorted(order) {
+		ids := make([]int, len(desired))
+
+		for i, j := range matched {
+			if j >= 0 {
+				ids[i] = current[j].ID
+			}
+		}
+
+		changes = append(changes, InstanceConfigInterfaceChange{
+			Action: InstanceConfigInterfaceReorder, DesiredIndex: -1, IDs: ids,
+		})
+	}
+
+	return changes, nil
+}
+
+// ApplyInstanceConfigInterfaces changes the interfaces of the config with the provided ID to
+// the desired interfaces using the calls computed by PlanInstanceConfigInterfaces. When dryRun
+// is set the planned changes are returned without being applied.
+func (c *Client) ApplyInstanceConfigInterfaces(
+	ctx context.Context,
+	linodeID, configID int,
+	desired []InstanceConfigInterfaceCreateOptions,
+	dryRun bool,
+) ([]InstanceConfigInterfaceChange, error) {
+	current, err := c.ListInstanceConfigInterfaces(ctx, linodeID, configID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := PlanInstanceConfigInterfaces(current, desired)
+	if err != nil || dryRun {
+		return changes, err
+	}
+
+	created := make(map[int]int)
+
+	for i, change := range changes {
+		switch change.Action {
+		case InstanceConfigInterfaceDelete:
+			err = c.DeleteInstanceConfigInterface(ctx, linodeID, configID, change.InterfaceID)
+		case InstanceConfigInterfaceUpdate:
+			err = c.updateInstanceConfigInterfacePlanned(ctx, linodeID, configID, change.InterfaceID, *change.Update)
+		case InstanceConfigInterfaceAppend:
+			var iface *InstanceConfigInterface
+
+			if iface, err = c.AppendInstanceConfigInterface(ctx, linodeID, configID, *change.Create); err == nil {
+				created[change.DesiredIndex] = iface.ID
+				changes[i].InterfaceID = iface.ID
+			}
+		case InstanceConfigInterfaceReorder:
+			for index, id := range created {
+				changes[i].IDs[index] = id
+			}
+
+			err = c.ReorderInstanceConfigInterfaces(ctx, linodeID, configID, InstanceConfigInterfacesReorderOptions{
+				IDs: changes[i].IDs,
+			})
+		}
+
+		if err != nil {
+			return changes, fmt.Errorf("failed to %s: %w", change, err)
+		}
+	}
+
+	return changes, nil
+}
+
+// instanceConfigInterfacePlannedUpdate is the body of a planned interface update. Primary is
+// always sent, as InstanceConfigInterfaceUpdateOptions omits a false Primary and a demotion
+// would otherwise be dropped.
+type instanceConfigInterfacePlannedUpdate struct {
+	Primary  *bool     `json:"primary"`
+	IPv4     *VPCIPv4  `json:"ipv4,omitempty"`
+	IPRanges *[]string `json:"ip_ranges,omitempty"`
+}
+
+func (c *Client) updateInstanceConfigInterfacePlanned(
+	ctx context.Context,
+	linodeID, configID, interfaceID int,
+	opts InstanceConfigInterfaceUpdateOptions,
+) error {
+	e := formatAPIPath("linode/instances/%d/configs/%d/interfaces/%d", linodeID, configID, interfaceID)
+
+	_, err := doPUTRequest[InstanceConfigInterface](ctx, c, e, instanceConfigInterfacePlannedUpdate{
+		Primary:  &opts.Primary,
+		IPv4:     opts.IPv4,
+		IPRanges: opts.IPRanges,
+	})
+
+	return err
+}
+
+// instanceConfigInterfaceIdentity identifies an interface across the current and desired lists
+func instanceConfigInterfaceIdentity(iface InstanceConfigInterfaceCreateOptions) string {
+	switch iface.Purpose {
+	case InterfacePurposeVLAN:
+		return "vlan:" + iface.Label
+	case InterfacePurposeVPC:
+		if iface.SubnetID != nil {
+			return fmt.Sprintf("vpc:%d", *iface.SubnetID)
+		}
+	}
+
+	return string(iface.Purpose)
+}
+
+// instanceConfigInterfaceNeedsReplace reports whether the current interface must be deleted
+// and appended again because the desired change cannot be made with an update. IPAM addresses
+// are immutable and NAT 1:1 mappings cannot be removed through the update options.
+func instanceConfigInterfaceNeedsReplace(current InstanceConfigInterface, desired InstanceConfigInterfaceCreateOptions) bool {
+	if current.IPAMAddress != desired.IPAMAddress {
+		return true
+	}
+
+	hasNAT := current.IPv4 != nil && current.IPv4.NAT1To1 != nil
+	wantsNAT := desired.IPv4 != nil && desired.IPv4.NAT1To1 != nil
+
+	return desired.Purpose == InterfacePurposeVPC && hasNAT && !wantsNAT
+}
+
+// instanceConfigInterfaceUpdate returns the update needed to turn the current interface into
+// the desired one, if any
+func instanceConfigInterfaceUpdate(
+	current InstanceConfigInterface,
+	desired InstanceConfigInterfaceCreateOptions,
+) (*InstanceConfigInterfaceUpdateOptions, bool) {
+	update := &InstanceConfigInterfaceUpdateOptions{Primary: desired.Primary}
+	changed := current.Primary != desired.Primary
+
+	if desired.Purpose == InterfacePurposeVPC {
+		if !vpcIPv4Satisfied(current.IPv4, desired.IPv4) {
+			update.IPv4 = desired.IPv4
+			changed = true
+		}
+
+		have := append([]string{}, current.IPRanges...)
+		want := append([]string{}, desired.IPRanges...)
+
+		sort.Strings(have)
+		sort.Strings(want)
+
+		if !reflect.DeepEqual(have, want) {
+			update.IPRanges = &want
+			changed = true
+		}
+	}
+
+	return update, changed
+}
+
+// vpcIPv4Satisfied reports whether the current VPC IPv4 settings satisfy the desired ones.
+// Empty desired settings or an empty VPC address accept any address, and a NAT 1:1 of
+// "any" accepts any mapped address.
+func vpcIPv4Satisfied(current, desired *VPCIPv4) bool {
+	if desired == nil {
+		return true
+	}
+
+	if current == nil {
+		current = &VPCIPv4{}
+	}
+
+	if desired.VPC != "" && desired.VPC != current.VPC {
+		return false
+	}
+
+	switch {
+	case desired.NAT1To1 == nil:
+		return current.NAT1To1 == nil
+	case current.NAT1To1 == nil:
+		return false
+	case *desired.NAT1To1 == "any":
+		return true
+	}
+
+	return *desired.NAT1To1 == *current.NAT1To1
+}
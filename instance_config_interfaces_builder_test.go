@@ -0,0 +1,97 @@
+package linodego
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstanceConfigInterfacesBuilder(t *testing.T) {
+	interfaces, err := NewInstanceConfigInterfacesBuilder().
+		Public().
+		VLAN("backend", "10.0.0.1/24").
+		VPC(5, &VPCIPv4{VPC: "10.1.0.10", NAT1To1: Pointer("any")}, "10.1.0.32/28").Primary().
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(interfaces) != 3 || !interfaces[2].Primary || *interfaces[2].SubnetID != 5 {
+		t.Fatalf("unexpected interfaces: %+v", interfaces)
+	}
+
+	invalid := [][]InstanceConfigInterfaceCreateOptions{
+		{{Purpose: InterfacePurposeVLAN, Label: "a"}, {Purpose: InterfacePurposePublic}},
+		{{Purpose: InterfacePurposePublic, Primary: true}, {Purpose: InterfacePurposeVPC, SubnetID: Pointer(1), Primary: true}},
+		{{Purpose: InterfacePurposeVLAN, Label: "a", Primary: true}},
+		{{Purpose: InterfacePurposeVLAN, Label: "a", IPAMAddress: "10.0.0.1"}},
+		{{Purpose: InterfacePurposeVLAN, Label: "a"}, {Purpose: InterfacePurposeVLAN, Label: "a"}},
+		{{Purpose: InterfacePurposeVPC, SubnetID: Pointer(1), IPv4: &VPCIPv4{NAT1To1: Pointer("all")}}},
+		{{Purpose: InterfacePurposeVPC}},
+	}
+
+	for i, ifaces := range invalid {
+		if err := ValidateInstanceConfigInterfaces(ifaces); err == nil {
+			t.Errorf("expected case %d to be invalid", i)
+		}
+	}
+}
+
+func TestPlanInstanceConfigInterfaces(t *testing.T) {
+	current := []InstanceConfigInterface{
+		{ID: 1, Purpose: InterfacePurposePublic, Primary: true},
+		{ID: 2, Purpose: InterfacePurposeVLAN, Label: "old", IPAMAddress: "10.0.0.1/24"},
+		{ID: 3, Purpose: InterfacePurposeVPC, SubnetID: Pointer(5), IPv4: &VPCIPv4{VPC: "10.1.0.10"}},
+	}
+
+	desired, err := NewInstanceConfigInterfacesBuilder().
+		Public().
+		VPC(5, &VPCIPv4{NAT1To1: Pointer("any")}).Primary().
+		VLAN("new", "").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := PlanInstanceConfigInterfaces(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := make([]InstanceConfigInterfaceAction, len(changes))
+	for i, change := range changes {
+		actions[i] = change.Action
+	}
+
+	expected := []InstanceConfigInterfaceAction{
+		InstanceConfigInterfaceDelete,
+		InstanceConfigInterfaceUpdate,
+		InstanceConfigInterfaceUpdate,
+		InstanceConfigInterfaceAppend,
+	}
+
+	if !reflect.DeepEqual(actions, expected) {
+		t.Fatalf("unexpected changes: %v", changes)
+	}
+
+	if changes[1].InterfaceID != 1 || changes[1].Update.Primary || changes[2].InterfaceID != 3 || !changes[2].Update.Primary {
+		t.Errorf("expected the old primary to be cleared first: %v", changes)
+	}
+
+	// Swapping the VLAN and VPC interfaces only requires a reorder
+	current = []InstanceConfigInterface{
+		{ID: 1, Purpose: InterfacePurposeVLAN, Label: "backend"},
+		{ID: 2, Purpose: InterfacePurposeVPC, SubnetID: Pointer(5)},
+	}
+
+	changes, err = PlanInstanceConfigInterfaces(current, []InstanceConfigInterfaceCreateOptions{
+		{Purpose: InterfacePurposeVPC, SubnetID: Pointer(5)},
+		{Purpose: InterfacePurposeVLAN, Label: "backend"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 1 || changes[0].Action != InstanceConfigInterfaceReorder || !reflect.DeepEqual(changes[0].IDs, []int{2, 1}) {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
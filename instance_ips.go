@@ -2,6 +2,8 @@ package linodego
 
 import (
 	"context"
+	"fmt"
+	"net/netip"
 )
 
 // InstanceIPAddressResponse contains the IPv4 and IPv6 details for an Instance
@@ -78,6 +80,41 @@ type IPv6Range struct {
 	Linodes []int `json:"linodes"`
 }
 
+// NetPrefix returns the range as a masked netip.Prefix
+func (r IPv6Range) NetPrefix() (netip.Prefix, error) {
+	addr, err := netip.ParseAddr(r.Range)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to parse range %s: %w", r.Range, err)
+	}
+
+	prefix, err := addr.Prefix(r.Prefix)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid prefix length %d for range %s: %w", r.Prefix, r.Range, err)
+	}
+
+	return prefix, nil
+}
+
+// RouteTargetAddr returns the address the range is routed to
+func (r IPv6Range) RouteTargetAddr() (netip.Addr, error) {
+	return netip.ParseAddr(r.RouteTarget)
+}
+
+// Addr returns the address of the IP as a netip.Addr
+func (i InstanceIP) Addr() (netip.Addr, error) {
+	return netip.ParseAddr(i.Address)
+}
+
+// NetPrefix returns the network the IP belongs to as a masked netip.Prefix
+func (i InstanceIP) NetPrefix() (netip.Prefix, error) {
+	addr, err := i.Addr()
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return addr.Prefix(i.Prefix)
+}
+
 // InstanceIPType constants start with IPType and include Linode Instance IP Types
 type InstanceIPType string
 
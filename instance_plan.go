@@ -0,0 +1,442 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+)
+
+// InstancePlanSeverity indicates whether an InstancePlanIssue blocks a change
+type InstancePlanSeverity string
+
+// InstancePlanSeverity constants are the severities an InstancePlanIssue can have
+const (
+	InstancePlanSeverityError   InstancePlanSeverity = "error"
+	InstancePlanSeverityWarning InstancePlanSeverity = "warning"
+)
+
+// InstancePlanCheck identifies the check that produced an InstancePlanIssue
+type InstancePlanCheck string
+
+// InstancePlanCheck constants are the checks performed by the instance planner
+const (
+	InstancePlanCheckStatus         InstancePlanCheck = "status"
+	InstancePlanCheckType           InstancePlanCheck = "type"
+	InstancePlanCheckAvailability   InstancePlanCheck = "availability"
+	InstancePlanCheckRegion         InstancePlanCheck = "region"
+	InstancePlanCheckCapability     InstancePlanCheck = "capability"
+	InstancePlanCheckDisk           InstancePlanCheck = "disk"
+	InstancePlanCheckGPU            InstancePlanCheck = "gpu"
+	InstancePlanCheckPlacementGroup InstancePlanCheck = "placement_group"
+)
+
+// InstancePlanIssue represents a single reason why a planned change may fail
+type InstancePlanIssue struct {
+	Severity InstancePlanSeverity
+	Check    InstancePlanCheck
+	Message  string
+}
+
+func (i InstancePlanIssue) String() string {
+	return fmt.Sprintf("[%s] %s: %s", i.Severity, i.Check, i.Message)
+}
+
+// InstancePlanOptions describes a change to an existing instance
+type InstancePlanOptions struct {
+	// Type is the target Linode type; leave empty to keep the current type
+	Type string
+
+	// Region is the target region; leave empty to keep the current region
+	Region string
+
+	// PlacementGroupID is the placement group to migrate into; leave 0 to keep the current one
+	PlacementGroupID int
+}
+
+// InstancePlan is the feasibility report of a planned instance creation, resize or migration
+type InstancePlan struct {
+	Instance         *Instance
+	CurrentType      *LinodeType
+	TargetType       *LinodeType
+	TargetRegion     *Region
+	PlacementGroupID int
+
+	Resize  bool
+	Migrate bool
+
+	Issues []InstancePlanIssue
+}
+
+// Feasible returns true if the plan contains no error issues
+func (p *InstancePlan) Feasible() bool {
+	return len(p.Errors()) == 0
+}
+
+// Errors returns all issues that will cause the change to be rejected
+func (p *InstancePlan) Errors() []InstancePlanIssue {
+	return p.issuesWithSeverity(InstancePlanSeverityError)
+}
+
+// Warnings returns all issues that do not block the change
+func (p *InstancePlan) Warnings() []InstancePlanIssue {
+	return p.issuesWithSeverity(InstancePlanSeverityWarning)
+}
+
+// ResizeOptions returns the options to pass to ResizeInstance, or nil if no resize is planned
+func (p *InstancePlan) ResizeOptions() *InstanceResizeOptions {
+	if !p.Resize || p.TargetType == nil {
+		return nil
+	}
+
+	return &InstanceResizeOptions{Type: p.TargetType.ID}
+}
+
+// MigrateOptions returns the options to pass to MigrateInstance, or nil if no migration is planned
+func (p *InstancePlan) MigrateOptions() *InstanceMigrateOptions {
+	if !p.Migrate || p.TargetRegion == nil {
+		return nil
+	}
+
+	opts := &InstanceMigrateOptions{Region: p.TargetRegion.ID}
+
+	if p.PlacementGroupID != 0 {
+		opts.PlacementGroup = &InstanceCreatePlacementGroupOptions{ID: p.PlacementGroupID}
+	}
+
+	return opts
+}
+
+func (p *InstancePlan) issuesWithSeverity(severity InstancePlanSeverity) []InstancePlanIssue {
+	result := make([]InstancePlanIssue, 0)
+
+	for _, issue := range p.Issues {
+		if issue.Severity == severity {
+			result = append(result, issue)
+		}
+	}
+
+	return result
+}
+
+func (p *InstancePlan) addError(check InstancePlanCheck, format string, args ...any) {
+	p.Issues = append(p.Issues, InstancePlanIssue{
+		Severity: InstancePlanSeverityError,
+		Check:    check,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (p *InstancePlan) addWarning(check InstancePlanCheck, format string, args ...any) {
+	p.Issues = append(p.Issues, InstancePlanIssue{
+		Severity: InstancePlanSeverityWarning,
+		Check:    check,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// PlanInstanceChange checks whether the Linode with the provided ID can be resized
+// and/or migrated as described by opts without performing any mutation.
+func (c *Client) PlanInstanceChange(ctx context.Context, linodeID int, opts InstancePlanOptions) (*InstancePlan, error) {
+	instance, err := c.GetInstance(ctx, linodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &InstancePlan{
+		Instance:         instance,
+		PlacementGroupID: opts.PlacementGroupID,
+	}
+
+	if instance.Status != InstanceRunning && instance.Status != InstanceOffline {
+		plan.addError(InstancePlanCheckStatus, "instance %d is %s", instance.ID, instance.Status)
+	}
+
+	if plan.CurrentType, err = c.planGetType(ctx, plan, instance.Type); err != nil {
+		return nil, err
+	}
+
+	targetTypeID := instance.Type
+	if opts.Type != "" {
+		targetTypeID = opts.Type
+		plan.Resize = opts.Type != instance.Type
+	}
+
+	if plan.Resize {
+		if plan.TargetType, err = c.planGetType(ctx, plan, targetTypeID); err != nil {
+			return nil, err
+		}
+	} else {
+		plan.TargetType = plan.CurrentType
+	}
+
+	targetRegionID := instance.Region
+	if opts.Region != "" {
+		targetRegionID = opts.Region
+		plan.Migrate = opts.Region != instance.Region
+	}
+
+	if plan.TargetRegion, err = c.planGetRegion(ctx, plan, targetRegionID); err != nil {
+		return nil, err
+	}
+
+	if plan.Resize || plan.Migrate {
+		if err := c.planCheckAvailability(ctx, plan, targetRegionID, targetTypeID); err != nil {
+			return nil, err
+		}
+	}
+
+	if plan.Resize {
+		if err := c.planCheckResize(ctx, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	planCheckRegionCapabilities(plan, instance.Backups != nil && instance.Backups.Enabled,
+		instance.DiskEncryption == InstanceDiskEncryptionEnabled)
+
+	pgID := opts.PlacementGroupID
+	if pgID == 0 && instance.PlacementGroup != nil {
+		pgID = instance.PlacementGroup.ID
+	}
+
+	if pgID != 0 && (plan.Migrate || opts.PlacementGroupID != 0) {
+		if err := c.planCheckPlacementGroup(ctx, plan, pgID); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// PlanInstanceCreate checks whether an instance can be created with the provided
+// options without creating it.
+func (c *Client) PlanInstanceCreate(ctx context.Context, opts InstanceCreateOptions) (*InstancePlan, error) {
+	var err error
+
+	plan := &InstancePlan{}
+
+	if plan.TargetType, err = c.planGetType(ctx, plan, opts.Type); err != nil {
+		return nil, err
+	}
+
+	if plan.TargetRegion, err = c.planGetRegion(ctx, plan, opts.Region); err != nil {
+		return nil, err
+	}
+
+	if err := c.planCheckAvailability(ctx, plan, opts.Region, opts.Type); err != nil {
+		return nil, err
+	}
+
+	planCheckRegionCapabilities(plan, opts.BackupsEnabled, opts.DiskEncryption == InstanceDiskEncryptionEnabled)
+
+	if plan.TargetRegion != nil {
+		if opts.Metadata != nil && !regionHasCapability(plan.TargetRegion, CapabilityMetadata) {
+			plan.addError(InstancePlanCheckCapability,
+				"region %s does not support %s", plan.TargetRegion.ID, CapabilityMetadata)
+		}
+
+		for _, iface := range opts.Interfaces {
+			capability := ""
+
+			switch iface.Purpose {
+			case InterfacePurposeVLAN:
+				capability = CapabilityVlans
+			case InterfacePurposeVPC:
+				capability = CapabilityVPCs
+			}
+
+			if capability != "" && !regionHasCapability(plan.TargetRegion, capability) {
+				plan.addError(InstancePlanCheckCapability,
+					"region %s does not support %s", plan.TargetRegion.ID, capability)
+			}
+		}
+	}
+
+	if opts.PlacementGroup != nil {
+		plan.PlacementGroupID = opts.PlacementGroup.ID
+
+		if err := c.planCheckPlacementGroup(ctx, plan, opts.PlacementGroup.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+func (c *Client) planGetType(ctx context.Context, plan *InstancePlan, typeID string) (*LinodeType, error) {
+	linodeType, err := c.GetType(ctx, typeID)
+	if err != nil {
+		if IsNotFound(err) {
+			plan.addError(InstancePlanCheckType, "type %s does not exist", typeID)
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if linodeType.Successor != "" {
+		plan.addWarning(InstancePlanCheckType,
+			"type %s is deprecated in favor of %s", linodeType.ID, linodeType.Successor)
+	}
+
+	return linodeType, nil
+}
+
+func (c *Client) planGetRegion(ctx context.Context, plan *InstancePlan, regionID string) (*Region, error) {
+	region, err := c.GetRegion(ctx, regionID)
+	if err != nil {
+		if IsNotFound(err) {
+			plan.addError(InstancePlanCheckRegion, "region %s does not exist", regionID)
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if region.Status != "" && region.Status != "ok" {
+		plan.addWarning(InstancePlanCheckRegion, "region %s has status %s", region.ID, region.Status)
+	}
+
+	return region, nil
+}
+
+func (c *Client) planCheckAvailability(ctx context.Context, plan *InstancePlan, regionID, typeID string) error {
+	availability, err := c.ListRegionsAvailability(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range availability {
+		if a.Region == regionID && a.Plan == typeID && !a.Available {
+			plan.addError(InstancePlanCheckAvailability, "type %s is sold out in region %s", typeID, regionID)
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) planCheckResize(ctx context.Context, plan *InstancePlan) error {
+	if plan.CurrentType == nil || plan.TargetType == nil {
+		return nil
+	}
+
+	if (plan.CurrentType.Class == ClassGPU) != (plan.TargetType.Class == ClassGPU) {
+		plan.addError(InstancePlanCheckGPU,
+			"cannot resize between GPU and non-GPU types (%s to %s)", plan.CurrentType.ID, plan.TargetType.ID)
+	}
+
+	if plan.TargetType.Disk >= plan.CurrentType.Disk {
+		return nil
+	}
+
+	disks, err := c.ListInstanceDisks(ctx, plan.Instance.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	allocated := 0
+	for _, disk := range disks {
+		allocated += disk.Size
+	}
+
+	if allocated > plan.TargetType.Disk {
+		plan.addError(InstancePlanCheckDisk,
+			"instance has %d MB of disks allocated but type %s only provides %d MB",
+			allocated, plan.TargetType.ID, plan.TargetType.Disk)
+	}
+
+	return nil
+}
+
+func (c *Client) planCheckPlacementGroup(ctx context.Context, plan *InstancePlan, pgID int) error {
+	pg, err := c.GetPlacementGroup(ctx, pgID)
+	if err != nil {
+		if IsNotFound(err) {
+			plan.addError(InstancePlanCheckPlacementGroup, "placement group %d does not exist", pgID)
+			return nil
+		}
+
+		return err
+	}
+
+	if plan.TargetRegion == nil {
+		return nil
+	}
+
+	if pg.Region != plan.TargetRegion.ID {
+		plan.addError(InstancePlanCheckPlacementGroup,
+			"placement group %d is in region %s, not %s", pg.ID, pg.Region, plan.TargetRegion.ID)
+		return nil
+	}
+
+	if !regionHasCapability(plan.TargetRegion, CapabilityPlacementGroup) {
+		plan.addError(InstancePlanCheckCapability,
+			"region %s does not support %s", plan.TargetRegion.ID, CapabilityPlacementGroup)
+	}
+
+	limits := plan.TargetRegion.PlacementGroupLimits
+	if limits == nil || limits.MaximumLinodesPerPG == 0 {
+		return nil
+	}
+
+	members := len(pg.Members)
+	if plan.Instance != nil {
+		for _, m := range pg.Members {
+			if m.LinodeID == plan.Instance.ID {
+				members--
+			}
+		}
+	}
+
+	if members >= limits.MaximumLinodesPerPG {
+		plan.addError(InstancePlanCheckPlacementGroup,
+			"placement group %d is full (%d of %d Linodes)", pg.ID, members, limits.MaximumLinodesPerPG)
+	}
+
+	if !pg.IsCompliant && pg.PlacementGroupPolicy == PlacementGroupPolicyStrict {
+		plan.addWarning(InstancePlanCheckPlacementGroup,
+			"placement group %d is not compliant with its strict policy", pg.ID)
+	}
+
+	return nil
+}
+
+func planCheckRegionCapabilities(plan *InstancePlan, backups, diskEncryption bool) {
+	region := plan.TargetRegion
+	if region == nil {
+		return
+	}
+
+	required := []string{CapabilityLinodes}
+
+	if plan.TargetType != nil && plan.TargetType.Class == ClassGPU {
+		required = append(required, CapabilityGPU)
+	}
+
+	if plan.TargetType != nil && plan.TargetType.Class == ClassPremium {
+		required = append(required, CapabilityPremiumPlans)
+	}
+
+	if backups {
+		required = append(required, CapabilityBackups)
+	}
+
+	if diskEncryption {
+		required = append(required, CapabilityDiskEncryption)
+	}
+
+	for _, capability := range required {
+		if !regionHasCapability(region, capability) {
+			plan.addError(InstancePlanCheckCapability, "region %s does not support %s", region.ID, capability)
+		}
+	}
+}
+
+func regionHasCapability(region *Region, capability string) bool {
+	for _, c := range region.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+
+	return false
+}
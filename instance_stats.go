@@ -2,6 +2,7 @@ package linodego
 
 import (
 	"context"
+	"time"
 )
 
 // StatsNet represents a network stats object
@@ -32,6 +33,107 @@ type InstanceStats struct {
 	Data  InstanceStatsData `json:"data"`
 }
 
+// StatsNetSeries represents the decoded time series of a network stats object
+type StatsNetSeries struct {
+	In         TimeSeries
+	Out        TimeSeries
+	PrivateIn  TimeSeries
+	PrivateOut TimeSeries
+}
+
+// InstanceStatsSeries represents the decoded time series of an instance stats data object
+type InstanceStatsSeries struct {
+	CPU   TimeSeries
+	IO    TimeSeries
+	Swap  TimeSeries
+	NetV4 StatsNetSeries
+	NetV6 StatsNetSeries
+}
+
+// Series decodes the raw stats data into typed time series
+func (d InstanceStatsData) Series() InstanceStatsSeries {
+	return InstanceStatsSeries{
+		CPU:   NewTimeSeries(d.CPU),
+		IO:    NewTimeSeries(d.IO.IO),
+		Swap:  NewTimeSeries(d.IO.Swap),
+		NetV4: d.NetV4.Series(),
+		NetV6: d.NetV6.Series(),
+	}
+}
+
+// Series decodes the raw network stats into typed time series
+func (n StatsNet) Series() StatsNetSeries {
+	return StatsNetSeries{
+		In:         NewTimeSeries(n.In),
+		Out:        NewTimeSeries(n.Out),
+		PrivateIn:  NewTimeSeries(n.PrivateIn),
+		PrivateOut: NewTimeSeries(n.PrivateOut),
+	}
+}
+
+// Between returns the samples of every series within the inclusive range [start, end]
+func (s InstanceStatsSeries) Between(start, end time.Time) InstanceStatsSeries {
+	return InstanceStatsSeries{
+		CPU:   s.CPU.Between(start, end),
+		IO:    s.IO.Between(start, end),
+		Swap:  s.Swap.Between(start, end),
+		NetV4: s.NetV4.between(start, end),
+		NetV6: s.NetV6.between(start, end),
+	}
+}
+
+// Metrics returns every series keyed by a stable metric name, for use with
+// StatsSeries.WriteCSV and StatsSeries.WritePrometheus.
+func (s InstanceStatsSeries) Metrics() StatsSeries {
+	return StatsSeries{
+		"cpu":               s.CPU,
+		"io":                s.IO,
+		"swap":              s.Swap,
+		"netv4_in":          s.NetV4.In,
+		"netv4_out":         s.NetV4.Out,
+		"netv4_private_in":  s.NetV4.PrivateIn,
+		"netv4_private_out": s.NetV4.PrivateOut,
+		"netv6_in":          s.NetV6.In,
+		"netv6_out":         s.NetV6.Out,
+		"netv6_private_in":  s.NetV6.PrivateIn,
+		"netv6_private_out": s.NetV6.PrivateOut,
+	}
+}
+
+func (s StatsNetSeries) between(start, end time.Time) StatsNetSeries {
+	return StatsNetSeries{
+		In:         s.In.Between(start, end),
+		Out:        s.Out.Between(start, end),
+		PrivateIn:  s.PrivateIn.Between(start, end),
+		PrivateOut: s.PrivateOut.Between(start, end),
+	}
+}
+
+// MergeInstanceStatsSeries combines the given series into one continuous series.
+// Samples from later arguments take precedence when timestamps overlap.
+func MergeInstanceStatsSeries(series ...InstanceStatsSeries) InstanceStatsSeries {
+	var result InstanceStatsSeries
+
+	for _, s := range series {
+		result.CPU = MergeTimeSeries(result.CPU, s.CPU)
+		result.IO = MergeTimeSeries(result.IO, s.IO)
+		result.Swap = MergeTimeSeries(result.Swap, s.Swap)
+		result.NetV4 = mergeStatsNetSeries(result.NetV4, s.NetV4)
+		result.NetV6 = mergeStatsNetSeries(result.NetV6, s.NetV6)
+	}
+
+	return result
+}
+
+func mergeStatsNetSeries(a, b StatsNetSeries) StatsNetSeries {
+	return StatsNetSeries{
+		In:         MergeTimeSeries(a.In, b.In),
+		Out:        MergeTimeSeries(a.Out, b.Out),
+		PrivateIn:  MergeTimeSeries(a.PrivateIn, b.PrivateIn),
+		PrivateOut: MergeTimeSeries(a.PrivateOut, b.PrivateOut),
+	}
+}
+
 // GetInstanceStats gets the template with the provided ID
 func (c *Client) GetInstanceStats(ctx context.Context, linodeID int) (*InstanceStats, error) {
 	e := formatAPIPath("linode/instances/%d/stats", linodeID)
@@ -53,3 +155,35 @@ func (c *Client) GetInstanceStatsByDate(ctx context.Context, linodeID int, year
 
 	return response, nil
 }
+
+// GetInstanceStatsSeries gets the decoded stats of the Linode with the provided ID
+// from since until now. Monthly stats are fetched with GetInstanceStatsByDate for
+// every month in the range and merged with the most recent stats from GetInstanceStats.
+func (c *Client) GetInstanceStatsSeries(ctx context.Context, linodeID int, since time.Time) (*InstanceStatsSeries, error) {
+	now := time.Now().UTC()
+	since = since.UTC()
+
+	var series []InstanceStatsSeries
+
+	month := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for !month.After(now) {
+		stats, err := c.GetInstanceStatsByDate(ctx, linodeID, month.Year(), int(month.Month()))
+		if err != nil {
+			return nil, err
+		}
+
+		series = append(series, stats.Data.Series())
+		month = month.AddDate(0, 1, 0)
+	}
+
+	stats, err := c.GetInstanceStats(ctx, linodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	series = append(series, stats.Data.Series())
+
+	result := MergeInstanceStatsSeries(series...).Between(since, time.Time{})
+
+	return &result, nil
+}
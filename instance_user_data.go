@@ -0,0 +1,295 @@
+package linodego
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+)
+
+// MaxUserDataSize is the maximum size in bytes of the base64-encoded user data accepted
+// by the Linode Metadata service.
+const MaxUserDataSize = 65535
+
+// UserDataContentType constants are the cloud-init content types of user data parts
+const (
+	UserDataContentTypeCloudConfig = "text/cloud-config"
+	UserDataContentTypeShellScript = "text/x-shellscript"
+	UserDataContentTypeBoothook    = "text/cloud-boothook"
+)
+
+// CloudConfigUser represents a user entry of a cloud-config document
+type CloudConfigUser struct {
+	Name              string   `json:"name"`
+	Gecos             string   `json:"gecos,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	Shell             string   `json:"shell,omitempty"`
+	Sudo              string   `json:"sudo,omitempty"`
+	SSHAuthorizedKeys []string `json:"ssh_authorized_keys,omitempty"`
+	LockPasswd        *bool    `json:"lock_passwd,omitempty"`
+}
+
+// CloudConfigWriteFile represents a write_files entry of a cloud-config document
+type CloudConfigWriteFile struct {
+	Path        string `json:"path"`
+	Content     string `json:"content"`
+	Owner       string `json:"owner,omitempty"`
+	Permissions string `json:"permissions,omitempty"`
+	Encoding    string `json:"encoding,omitempty"`
+	Append      *bool  `json:"append,omitempty"`
+}
+
+// CloudConfig represents a cloud-init #cloud-config document
+type CloudConfig struct {
+	Hostname          string                 `json:"hostname,omitempty"`
+	FQDN              string                 `json:"fqdn,omitempty"`
+	Timezone          string                 `json:"timezone,omitempty"`
+	Users             []CloudConfigUser      `json:"users,omitempty"`
+	SSHAuthorizedKeys []string               `json:"ssh_authorized_keys,omitempty"`
+	SSHPwAuth         *bool                  `json:"ssh_pwauth,omitempty"`
+	PackageUpdate     *bool                  `json:"package_update,omitempty"`
+	PackageUpgrade    *bool                  `json:"package_upgrade,omitempty"`
+	Packages          []string               `json:"packages,omitempty"`
+	WriteFiles        []CloudConfigWriteFile `json:"write_files,omitempty"`
+	BootCmd           []string               `json:"bootcmd,omitempty"`
+	RunCmd            []string               `json:"runcmd,omitempty"`
+}
+
+// Render returns the cloud-config document. The body is emitted as JSON,
+// which cloud-init accepts as a subset of YAML.
+func (c CloudConfig) Render() ([]byte, error) {
+	body, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte("#cloud-config\n"), body...), nil
+}
+
+// UserDataPart represents a single part of multipart user data
+type UserDataPart struct {
+	ContentType string
+	Filename    string
+	Content     []byte
+}
+
+// UserDataBuilder composes cloud-init user data for the Linode Metadata service
+type UserDataBuilder struct {
+	parts []UserDataPart
+	gzip  bool
+	err   error
+}
+
+// NewUserDataBuilder returns an empty UserDataBuilder
+func NewUserDataBuilder() *UserDataBuilder {
+	return &UserDataBuilder{}
+}
+
+// AddCloudConfig adds a #cloud-config document to the user data. A document that cannot be
+// rendered is reported by Build.
+func (b *UserDataBuilder) AddCloudConfig(config CloudConfig) *UserDataBuilder {
+	content, err := config.Render()
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("failed to render cloud-config: %w", err)
+		}
+
+		return b
+	}
+
+	return b.AddPart(UserDataPart{
+		ContentType: UserDataContentTypeCloudConfig,
+		Filename:    fmt.Sprintf("cloud-config-%d.yaml", len(b.parts)),
+		Content:     content,
+	})
+}
+
+// AddShellScript adds a shell script to be run once on first boot
+func (b *UserDataBuilder) AddShellScript(script string) *UserDataBuilder {
+	return b.AddPart(UserDataPart{
+		ContentType: UserDataContentTypeShellScript,
+		Filename:    fmt.Sprintf("script-%d.sh", len(b.parts)),
+		Content:     []byte(script),
+	})
+}
+
+// AddPart adds an arbitrary part to the user data
+func (b *UserDataBuilder) AddPart(part UserDataPart) *UserDataBuilder {
+	b.parts = append(b.parts, part)
+	return b
+}
+
+// SetGzip forces the user data to be gzip-compressed. When disabled, the user
+// data is only compressed if its encoded form would otherwise exceed MaxUserDataSize.
+func (b *UserDataBuilder) SetGzip(enabled bool) *UserDataBuilder {
+	b.gzip = enabled
+	return b
+}
+
+// Build returns the raw, unencoded user data. A single part is returned as-is
+// and multiple parts are combined into a multipart/mixed MIME document.
+func (b *UserDataBuilder) Build() ([]byte, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	var data []byte
+
+	switch len(b.parts) {
+	case 0:
+		return nil, fmt.Errorf("user data contains no parts")
+	case 1:
+		data = b.parts[0].Content
+	default:
+		var err error
+		if data, err = b.buildMultipart(); err != nil {
+			return nil, err
+		}
+	}
+
+	if b.gzip || encodedUserDataSize(data) > MaxUserDataSize {
+		compressed, err := gzipUserData(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if b.gzip || len(compressed) < len(data) {
+			data = compressed
+		}
+	}
+
+	if size := encodedUserDataSize(data); size > MaxUserDataSize {
+		return nil, fmt.Errorf("encoded user data is %d bytes, exceeding the limit of %d bytes", size, MaxUserDataSize)
+	}
+
+	return data, nil
+}
+
+// encodedUserDataSize returns the size of the user data once base64-encoded, which is
+// what the API limits
+func encodedUserDataSize(data []byte) int {
+	return base64.StdEncoding.EncodedLen(len(data))
+}
+
+// MetadataOptions builds the user data and encodes it into InstanceMetadataOptions
+func (b *UserDataBuilder) MetadataOptions() (*InstanceMetadataOptions, error) {
+	data, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstanceMetadataOptions{
+		UserData: base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+func (b *UserDataBuilder) buildMultipart() ([]byte, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for _, part := range b.parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", part.ContentType))
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Transfer-Encoding", "7bit")
+
+		if part.Filename != "" {
+			header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", part.Filename))
+		}
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := w.Write(part.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var result bytes.Buffer
+
+	fmt.Fprintf(&result, "Content-Type: multipart/mixed; boundary=%q\r\n", writer.Boundary())
+	result.WriteString("MIME-Version: 1.0\r\n\r\n")
+	result.Write(body.Bytes())
+
+	return result.Bytes(), nil
+}
+
+func gzipUserData(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ValidateImageCloudInit returns an error if the Image with the provided ID
+// does not support cloud-init user data.
+func (c *Client) ValidateImageCloudInit(ctx context.Context, imageID string) error {
+	image, err := c.GetImage(ctx, imageID)
+	if err != nil {
+		return err
+	}
+
+	if !image.HasCapability(ImageCapabilityCloudInit) {
+		return fmt.Errorf("image %s does not support cloud-init", imageID)
+	}
+
+	return nil
+}
+
+// SetInstanceCreateUserData validates that the image of opts supports cloud-init
+// and sets opts.Metadata to the user data built by userData.
+func (c *Client) SetInstanceCreateUserData(ctx context.Context, opts *InstanceCreateOptions, userData *UserDataBuilder) error {
+	metadata, err := c.userDataForImage(ctx, opts.Image, userData)
+	if err != nil {
+		return err
+	}
+
+	opts.Metadata = metadata
+
+	return nil
+}
+
+// SetInstanceRebuildUserData validates that the image of opts supports cloud-init
+// and sets opts.Metadata to the user data built by userData.
+func (c *Client) SetInstanceRebuildUserData(ctx context.Context, opts *InstanceRebuildOptions, userData *UserDataBuilder) error {
+	metadata, err := c.userDataForImage(ctx, opts.Image, userData)
+	if err != nil {
+		return err
+	}
+
+	opts.Metadata = metadata
+
+	return nil
+}
+
+func (c *Client) userDataForImage(ctx context.Context, imageID string, userData *UserDataBuilder) (*InstanceMetadataOptions, error) {
+	if imageID == "" {
+		return nil, fmt.Errorf("an image must be specified to use user data")
+	}
+
+	if err := c.ValidateImageCloudInit(ctx, imageID); err != nil {
+		return nil, err
+	}
+
+	return userData.MetadataOptions()
+}
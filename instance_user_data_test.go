@@ -0,0 +1,96 @@
+package linodego
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestUserDataBuilder_CloudConfig(t *testing.T) {
+	opts, err := NewUserDataBuilder().AddCloudConfig(CloudConfig{
+		SSHPwAuth: Pointer(false),
+		Packages:  []string{"nginx"},
+		RunCmd:    []string{"systemctl enable --now nginx"},
+	}).MetadataOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(opts.UserData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(string(data), "#cloud-config\n") {
+		t.Fatalf("expected cloud-config header, got %q", data)
+	}
+
+	if !strings.Contains(string(data), `"packages": [`) {
+		t.Fatalf("expected packages in cloud-config, got %q", data)
+	}
+
+	// An explicit false is rendered, while unset flags are left to cloud-init's defaults
+	if !strings.Contains(string(data), `"ssh_pwauth": false`) || strings.Contains(string(data), "package_update") {
+		t.Errorf("unexpected flags in cloud-config: %q", data)
+	}
+}
+
+func TestUserDataBuilder_Multipart(t *testing.T) {
+	data, err := NewUserDataBuilder().
+		AddCloudConfig(CloudConfig{Hostname: "web-1"}).
+		AddShellScript("#!/bin/sh\necho hello\n").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"Content-Type: multipart/mixed; boundary=",
+		"Content-Type: text/cloud-config",
+		"Content-Type: text/x-shellscript",
+		"echo hello",
+	} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("expected user data to contain %q", expected)
+		}
+	}
+}
+
+func TestUserDataBuilder_GzipWhenTooLarge(t *testing.T) {
+	b := NewUserDataBuilder().AddShellScript("#!/bin/sh\n" + strings.Repeat("echo hello\n", 10000))
+
+	data, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("expected gzip-compressed user data: %v", err)
+	}
+
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+
+	random := make([]byte, MaxUserDataSize+1)
+	rand.New(rand.NewSource(1)).Read(random)
+
+	b = NewUserDataBuilder().AddPart(UserDataPart{ContentType: UserDataContentTypeShellScript, Content: random})
+	if _, err := b.Build(); err == nil {
+		t.Fatal("expected an error for incompressible user data over the size limit")
+	}
+
+	// Under the limit unencoded, but over it once base64-encoded
+	random = make([]byte, MaxUserDataSize*3/4+3)
+	rand.New(rand.NewSource(2)).Read(random)
+
+	b = NewUserDataBuilder().AddPart(UserDataPart{ContentType: UserDataContentTypeShellScript, Content: random})
+	if _, err := b.Build(); err == nil {
+		t.Fatal("expected an error for user data over the size limit once encoded")
+	}
+}
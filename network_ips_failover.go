@@ -0,0 +1,290 @@
+package linodego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// IPResolver performs forward DNS lookups. *net.Resolver satisfies this interface.
+type IPResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// IPAddressRDNSUpdate describes a reverse DNS change for a single IP address
+type IPAddressRDNSUpdate struct {
+	Address string
+	RDNS    string
+}
+
+// IPAddressRDNSResult is the outcome of a single IPAddressRDNSUpdate
+type IPAddressRDNSResult struct {
+	Address string
+	RDNS    string
+	IP      *InstanceIP
+	Err     error
+}
+
+// ShareIPAddressesForFailover shares the given IPv4 addresses of the primary Linode with
+// the secondary Linode so that the secondary can take them over, e.g. with keepalived.
+// If no addresses are given, all public IPv4 addresses of the primary are shared.
+// Addresses already shared with the secondary are preserved. The resulting IP addresses
+// of the secondary are returned once the share has been verified.
+func (c *Client) ShareIPAddressesForFailover(
+	ctx context.Context,
+	primaryID, secondaryID int,
+	addresses ...string,
+) (*InstanceIPAddressResponse, error) {
+	if _, err := c.ensureSameRegion(ctx, primaryID, secondaryID); err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		primaryIPs, err := c.GetInstanceIPAddresses(ctx, primaryID)
+		if err != nil {
+			return nil, err
+		}
+
+		if primaryIPs.IPv4 != nil {
+			for _, ip := range primaryIPs.IPv4.Public {
+				addresses = append(addresses, ip.Address)
+			}
+		}
+
+		if len(addresses) == 0 {
+			return nil, fmt.Errorf("linode %d has no public IPv4 addresses to share", primaryID)
+		}
+	}
+
+	secondaryIPs, err := c.GetInstanceIPAddresses(ctx, secondaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]string, 0, len(addresses))
+	seen := make(map[string]bool)
+
+	if secondaryIPs.IPv4 != nil {
+		for _, ip := range secondaryIPs.IPv4.Shared {
+			if !seen[ip.Address] {
+				seen[ip.Address] = true
+				shared = append(shared, ip.Address)
+			}
+		}
+	}
+
+	for _, address := range addresses {
+		if !seen[address] {
+			seen[address] = true
+			shared = append(shared, address)
+		}
+	}
+
+	if err := c.ShareIPAddresses(ctx, IPAddressesShareOptions{
+		IPs:      shared,
+		LinodeID: secondaryID,
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := c.GetInstanceIPAddresses(ctx, secondaryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var actual []*InstanceIP
+	if result.IPv4 != nil {
+		actual = result.IPv4.Shared
+	}
+
+	if missing := missingIPAddresses(actual, addresses); len(missing) > 0 {
+		return nil, fmt.Errorf("addresses %v were not shared with linode %d", missing, secondaryID)
+	}
+
+	return result, nil
+}
+
+// AssignIPAddress moves the given IPv4 address to the Linode with the provided ID
+// and verifies that the Linode holds the address afterwards.
+func (c *Client) AssignIPAddress(ctx context.Context, address string, linodeID int) (*InstanceIPAddressResponse, error) {
+	ip, err := c.GetIPAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, err := c.GetInstance(ctx, linodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip.Region != instance.Region {
+		return nil, fmt.Errorf("address %s is in region %s but linode %d is in region %s",
+			address, ip.Region, linodeID, instance.Region)
+	}
+
+	if err := c.InstancesAssignIPs(ctx, LinodesAssignIPsOptions{
+		Region:      instance.Region,
+		Assignments: []LinodeIPAssignment{{Address: address, LinodeID: linodeID}},
+	}); err != nil {
+		return nil, err
+	}
+
+	return c.verifyIPAssignment(ctx, linodeID, address)
+}
+
+// SwapIPAddresses atomically exchanges addressA, currently assigned to linodeA, with
+// addressB, currently assigned to linodeB, using a single InstancesAssignIPs request.
+func (c *Client) SwapIPAddresses(ctx context.Context, linodeA int, addressA string, linodeB int, addressB string) error {
+	region, err := c.ensureSameRegion(ctx, linodeA, linodeB)
+	if err != nil {
+		return err
+	}
+
+	for _, current := range []LinodeIPAssignment{
+		{Address: addressA, LinodeID: linodeA},
+		{Address: addressB, LinodeID: linodeB},
+	} {
+		ip, err := c.GetIPAddress(ctx, current.Address)
+		if err != nil {
+			return err
+		}
+
+		if ip.LinodeID != current.LinodeID {
+			return fmt.Errorf("address %s is assigned to linode %d, not %d", current.Address, ip.LinodeID, current.LinodeID)
+		}
+	}
+
+	if err := c.InstancesAssignIPs(ctx, LinodesAssignIPsOptions{
+		Region: region,
+		Assignments: []LinodeIPAssignment{
+			{Address: addressA, LinodeID: linodeB},
+			{Address: addressB, LinodeID: linodeA},
+		},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := c.verifyIPAssignment(ctx, linodeA, addressB); err != nil {
+		return err
+	}
+
+	_, err = c.verifyIPAssignment(ctx, linodeB, addressA)
+
+	return err
+}
+
+// UpdateIPAddressesRDNS updates the reverse DNS of multiple IP addresses. If resolver is
+// not nil, each RDNS hostname must resolve to its address before it is applied. Every
+// update is attempted; the returned error joins the errors of all failed updates.
+func (c *Client) UpdateIPAddressesRDNS(
+	ctx context.Context,
+	updates []IPAddressRDNSUpdate,
+	resolver IPResolver,
+) ([]IPAddressRDNSResult, error) {
+	results := make([]IPAddressRDNSResult, len(updates))
+	errs := make([]error, 0)
+
+	for i, update := range updates {
+		results[i] = IPAddressRDNSResult{Address: update.Address, RDNS: update.RDNS}
+
+		if resolver != nil && update.RDNS != "" {
+			if err := checkForwardLookup(ctx, resolver, update.RDNS, update.Address); err != nil {
+				results[i].Err = err
+				errs = append(errs, err)
+
+				continue
+			}
+		}
+
+		rdns := copyString(&update.RDNS)
+		if update.RDNS == "" {
+			rdns = nil
+		}
+
+		ip, err := c.UpdateIPAddress(ctx, update.Address, IPAddressUpdateOptions{RDNS: rdns})
+		if err != nil {
+			results[i].Err = err
+			errs = append(errs, fmt.Errorf("failed to update rdns of %s: %w", update.Address, err))
+
+			continue
+		}
+
+		results[i].IP = ip
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (c *Client) ensureSameRegion(ctx context.Context, linodeA, linodeB int) (string, error) {
+	a, err := c.GetInstance(ctx, linodeA)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := c.GetInstance(ctx, linodeB)
+	if err != nil {
+		return "", err
+	}
+
+	if a.Region != b.Region {
+		return "", fmt.Errorf("linode %d is in region %s but linode %d is in region %s",
+			linodeA, a.Region, linodeB, b.Region)
+	}
+
+	return a.Region, nil
+}
+
+func (c *Client) verifyIPAssignment(ctx context.Context, linodeID int, address string) (*InstanceIPAddressResponse, error) {
+	ips, err := c.GetInstanceIPAddresses(ctx, linodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ips.IPv4 != nil {
+		for _, group := range [][]*InstanceIP{ips.IPv4.Public, ips.IPv4.Private, ips.IPv4.Reserved} {
+			if len(missingIPAddresses(group, []string{address})) == 0 {
+				return ips, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("address %s is not assigned to linode %d", address, linodeID)
+}
+
+func missingIPAddresses(actual []*InstanceIP, expected []string) []string {
+	found := make(map[string]bool, len(actual))
+	for _, ip := range actual {
+		found[ip.Address] = true
+	}
+
+	missing := make([]string, 0)
+
+	for _, address := range expected {
+		if !found[address] {
+			missing = append(missing, address)
+		}
+	}
+
+	return missing
+}
+
+func checkForwardLookup(ctx context.Context, resolver IPResolver, host, address string) error {
+	expected := net.ParseIP(address)
+	if expected == nil {
+		return fmt.Errorf("invalid ip address %s", address)
+	}
+
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+
+	for _, addr := range addrs {
+		if addr.IP.Equal(expected) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s does not resolve to %s", host, address)
+}
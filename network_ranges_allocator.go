@@ -0,0 +1,275 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"sort"
+	"sync"
+)
+
+// IPv6RangeAllocator hands out addresses and sub-prefixes from a routed IPv6 range
+// while tracking which parts of the range are in use. It is safe for concurrent use.
+type IPv6RangeAllocator struct {
+	mu     sync.Mutex
+	prefix netip.Prefix
+	used   []netip.Prefix
+}
+
+// IPv6RangeRoute describes where an IPv6 range is routed
+type IPv6RangeRoute struct {
+	Range       IPv6Range
+	Prefix      netip.Prefix
+	RouteTarget netip.Addr
+	LinodeIDs   []int
+}
+
+// NewIPv6RangeAllocator returns an allocator for the given IPv6 prefix
+func NewIPv6RangeAllocator(prefix netip.Prefix) (*IPv6RangeAllocator, error) {
+	if !prefix.IsValid() || !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+		return nil, fmt.Errorf("%s is not a valid IPv6 prefix", prefix)
+	}
+
+	return &IPv6RangeAllocator{prefix: prefix.Masked()}, nil
+}
+
+// NewIPv6RangeAllocatorFromRange returns an allocator for the given IPv6Range
+func NewIPv6RangeAllocatorFromRange(r IPv6Range) (*IPv6RangeAllocator, error) {
+	prefix, err := r.NetPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIPv6RangeAllocator(prefix)
+}
+
+// Prefix returns the prefix managed by the allocator
+func (a *IPv6RangeAllocator) Prefix() netip.Prefix {
+	return a.prefix
+}
+
+// Allocated returns all prefixes currently in use, ordered by address
+func (a *IPv6RangeAllocator) Allocated() []netip.Prefix {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]netip.Prefix, len(a.used))
+	copy(result, a.used)
+
+	return result
+}
+
+// Reserve marks the given prefix as in use
+func (a *IPv6RangeAllocator) Reserve(prefix netip.Prefix) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.reserve(prefix.Masked())
+}
+
+// ReserveAddr marks the given address as in use
+func (a *IPv6RangeAllocator) ReserveAddr(addr netip.Addr) error {
+	return a.Reserve(netip.PrefixFrom(addr, addr.BitLen()))
+}
+
+// Release returns the given prefix to the allocator. It returns false if the
+// prefix was not allocated.
+func (a *IPv6RangeAllocator) Release(prefix netip.Prefix) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prefix = prefix.Masked()
+
+	for i, p := range a.used {
+		if p == prefix {
+			a.used = append(a.used[:i], a.used[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReleaseAddr returns the given address to the allocator
+func (a *IPv6RangeAllocator) ReleaseAddr(addr netip.Addr) bool {
+	return a.Release(netip.PrefixFrom(addr, addr.BitLen()))
+}
+
+// AllocateAddr reserves and returns the lowest free address of the range.
+// The all-zeros address of the range is never handed out.
+func (a *IPv6RangeAllocator) AllocateAddr() (netip.Addr, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	prefix, err := a.allocate(128, a.prefix.Bits() < 128)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	return prefix.Addr(), nil
+}
+
+// AllocatePrefix reserves and returns the lowest free sub-prefix of the given length
+func (a *IPv6RangeAllocator) AllocatePrefix(bits int) (netip.Prefix, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.allocate(bits, false)
+}
+
+func (a *IPv6RangeAllocator) allocate(bits int, skipBase bool) (netip.Prefix, error) {
+	if bits < a.prefix.Bits() || bits > 128 {
+		return netip.Prefix{}, fmt.Errorf("cannot allocate a /%d from %s", bits, a.prefix)
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(128-bits))
+	base := addrToInt(a.prefix.Addr())
+	limit := new(big.Int).Add(base, prefixSize(a.prefix))
+
+	candidate := new(big.Int).Set(base)
+	if skipBase {
+		candidate.Add(candidate, size)
+	}
+
+	for new(big.Int).Add(candidate, size).Cmp(limit) <= 0 {
+		prefix := netip.PrefixFrom(intToAddr(candidate), bits)
+
+		conflict, ok := a.firstOverlap(prefix)
+		if !ok {
+			a.used = insertPrefix(a.used, prefix)
+			return prefix, nil
+		}
+
+		// Skip to the first aligned block after the conflicting prefix
+		end := new(big.Int).Add(addrToInt(conflict.Addr()), prefixSize(conflict))
+		candidate = alignUp(end, size)
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no free /%d left in %s", bits, a.prefix)
+}
+
+func (a *IPv6RangeAllocator) reserve(prefix netip.Prefix) error {
+	if !prefix.IsValid() || prefix.Bits() < a.prefix.Bits() || !a.prefix.Contains(prefix.Addr()) {
+		return fmt.Errorf("%s is not within %s", prefix, a.prefix)
+	}
+
+	if conflict, ok := a.firstOverlap(prefix); ok {
+		return fmt.Errorf("%s overlaps with %s which is already in use", prefix, conflict)
+	}
+
+	a.used = insertPrefix(a.used, prefix)
+
+	return nil
+}
+
+func (a *IPv6RangeAllocator) firstOverlap(prefix netip.Prefix) (netip.Prefix, bool) {
+	for _, p := range a.used {
+		if p.Overlaps(prefix) {
+			return p, true
+		}
+	}
+
+	return netip.Prefix{}, false
+}
+
+func insertPrefix(prefixes []netip.Prefix, prefix netip.Prefix) []netip.Prefix {
+	prefixes = append(prefixes, prefix)
+
+	sort.Slice(prefixes, func(i, j int) bool {
+		return prefixes[i].Addr().Less(prefixes[j].Addr())
+	})
+
+	return prefixes
+}
+
+func addrToInt(addr netip.Addr) *big.Int {
+	b := addr.As16()
+	return new(big.Int).SetBytes(b[:])
+}
+
+func intToAddr(i *big.Int) netip.Addr {
+	var b [16]byte
+	i.FillBytes(b[:])
+
+	return netip.AddrFrom16(b)
+}
+
+func prefixSize(prefix netip.Prefix) *big.Int {
+	return new(big.Int).Lsh(big.NewInt(1), uint(prefix.Addr().BitLen()-prefix.Bits()))
+}
+
+func alignUp(i, size *big.Int) *big.Int {
+	rem := new(big.Int).Mod(i, size)
+	if rem.Sign() == 0 {
+		return new(big.Int).Set(i)
+	}
+
+	return new(big.Int).Add(i, new(big.Int).Sub(size, rem))
+}
+
+// CreateIPv6RangeForInstance routes a new IPv6 range of the given prefix length to
+// the Linode with the provided ID. The created range is returned along with the
+// SLAAC address of the Linode that the range is routed to.
+func (c *Client) CreateIPv6RangeForInstance(ctx context.Context, linodeID int, prefixLength int) (*IPv6Range, *InstanceIP, error) {
+	ipRange, err := c.CreateIPv6Range(ctx, IPv6RangeCreateOptions{
+		LinodeID:     linodeID,
+		PrefixLength: prefixLength,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ips, err := c.GetInstanceIPAddresses(ctx, linodeID)
+	if err != nil {
+		return ipRange, nil, err
+	}
+
+	if ips.IPv6 == nil || ips.IPv6.SLAAC == nil {
+		return ipRange, nil, fmt.Errorf("linode %d has no SLAAC address", linodeID)
+	}
+
+	return ipRange, ips.IPv6.SLAAC, nil
+}
+
+// ListIPv6RangeRoutes lists every IPv6 range on the account along with the
+// address and Linodes it is routed to.
+func (c *Client) ListIPv6RangeRoutes(ctx context.Context) ([]IPv6RangeRoute, error) {
+	ranges, err := c.ListIPv6Ranges(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]IPv6RangeRoute, 0, len(ranges))
+
+	for _, r := range ranges {
+		// Linodes is only returned when fetching a single range
+		detailed, err := c.GetIPv6Range(ctx, r.Range)
+		if err != nil {
+			return nil, err
+		}
+
+		route := IPv6RangeRoute{
+			Range:     *detailed,
+			LinodeIDs: detailed.Linodes,
+		}
+
+		if route.Range.RouteTarget == "" {
+			route.Range.RouteTarget = r.RouteTarget
+		}
+
+		if route.Prefix, err = r.NetPrefix(); err != nil {
+			return nil, err
+		}
+
+		if route.Range.RouteTarget != "" {
+			if route.RouteTarget, err = route.Range.RouteTargetAddr(); err != nil {
+				return nil, fmt.Errorf("failed to parse route target of %s: %w", r.Range, err)
+			}
+		}
+
+		result = append(result, route)
+	}
+
+	return result, nil
+}
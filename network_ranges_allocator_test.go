@@ -0,0 +1,73 @@
+package linodego
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestIPv6Range_NetPrefix(t *testing.T) {
+	r := IPv6Range{Range: "2600:3c01:e000:3e6::", Prefix: 64}
+
+	prefix, err := r.NetPrefix()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prefix != netip.MustParsePrefix("2600:3c01:e000:3e6::/64") {
+		t.Fatalf("unexpected prefix: %s", prefix)
+	}
+}
+
+func TestIPv6RangeAllocator(t *testing.T) {
+	a, err := NewIPv6RangeAllocator(netip.MustParsePrefix("2001:db8::/64"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, err := a.AllocateAddr()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if addr != netip.MustParseAddr("2001:db8::1") {
+		t.Fatalf("unexpected first address: %s", addr)
+	}
+
+	if err := a.ReserveAddr(netip.MustParseAddr("2001:db8::2")); err != nil {
+		t.Fatal(err)
+	}
+
+	if addr, _ = a.AllocateAddr(); addr != netip.MustParseAddr("2001:db8::3") {
+		t.Fatalf("unexpected address after reservation: %s", addr)
+	}
+
+	// The first /112 contains allocated addresses, so the next aligned block is used
+	prefix, err := a.AllocatePrefix(112)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if prefix != netip.MustParsePrefix("2001:db8::1:0/112") {
+		t.Fatalf("unexpected sub-prefix: %s", prefix)
+	}
+
+	if err := a.Reserve(netip.MustParsePrefix("2001:db8::1:0/120")); err == nil {
+		t.Fatal("expected an error reserving an overlapping prefix")
+	}
+
+	if err := a.Reserve(netip.MustParsePrefix("2001:db9::/120")); err == nil {
+		t.Fatal("expected an error reserving a prefix outside of the range")
+	}
+
+	if !a.Release(prefix) {
+		t.Fatal("expected prefix to be released")
+	}
+
+	if len(a.Allocated()) != 3 {
+		t.Fatalf("expected 3 allocations, got %v", a.Allocated())
+	}
+
+	if _, err := a.AllocatePrefix(48); err == nil {
+		t.Fatal("expected an error allocating a prefix larger than the range")
+	}
+}
@@ -0,0 +1,140 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NodeBalancerHealthReport summarizes the configs, backends, traffic and transfer of a NodeBalancer
+type NodeBalancerHealthReport struct {
+	NodeBalancer *NodeBalancer
+	Configs      []NodeBalancerConfigHealth
+
+	// Stats holds the decoded connection and traffic series. It is empty if
+	// StatsAvailable is false, e.g. for newly created NodeBalancers.
+	Stats          NodeBalancerStatsSeries
+	StatsAvailable bool
+
+	Transfer NodeBalancerTransfer
+
+	// Healthy is true if no config has any issue
+	Healthy bool
+}
+
+// NodeBalancerConfigHealth summarizes the health of a single NodeBalancer config
+type NodeBalancerConfigHealth struct {
+	Config NodeBalancerConfig
+	Nodes  []NodeBalancerNode
+
+	// Up and Down count the backends by their reported status
+	Up   int
+	Down int
+
+	// Modes counts the backends by their mode
+	Modes map[NodeMode]int
+
+	// AllBackendsDown is true if the config has backends and none of them are up
+	AllBackendsDown bool
+
+	// SomeBackendsDown is true if at least one backend reports as down. The API does not
+	// report why a backend is down, so this does not tell which configured check failed.
+	SomeBackendsDown bool
+
+	Issues []string
+}
+
+// GetNodeBalancerHealth builds a health report for the NodeBalancer with the provided ID
+func (c *Client) GetNodeBalancerHealth(ctx context.Context, nodebalancerID int) (*NodeBalancerHealthReport, error) {
+	nb, err := c.GetNodeBalancer(ctx, nodebalancerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &NodeBalancerHealthReport{
+		NodeBalancer: nb,
+		Transfer:     nb.Transfer,
+		Healthy:      true,
+	}
+
+	configs, err := c.ListNodeBalancerConfigs(ctx, nodebalancerID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cfg := range configs {
+		nodes, err := c.ListNodeBalancerNodes(ctx, nodebalancerID, cfg.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		health := newNodeBalancerConfigHealth(cfg, nodes)
+		if len(health.Issues) > 0 {
+			report.Healthy = false
+		}
+
+		report.Configs = append(report.Configs, health)
+	}
+
+	stats, err := c.GetNodeBalancerStats(ctx, nodebalancerID)
+
+	switch {
+	case err == nil:
+		report.Stats = stats.Data.Series()
+		report.StatsAvailable = true
+	case ErrHasStatus(err, http.StatusBadRequest, http.StatusNotFound):
+		// Stats are not yet available for recently created NodeBalancers
+	default:
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func newNodeBalancerConfigHealth(cfg NodeBalancerConfig, nodes []NodeBalancerNode) NodeBalancerConfigHealth {
+	health := NodeBalancerConfigHealth{
+		Config: cfg,
+		Nodes:  nodes,
+		Modes:  make(map[NodeMode]int),
+	}
+
+	for _, node := range nodes {
+		health.Modes[node.Mode]++
+
+		if strings.EqualFold(node.Status, "up") {
+			health.Up++
+		} else if strings.EqualFold(node.Status, "down") {
+			health.Down++
+		}
+	}
+
+	if len(nodes) == 0 {
+		health.Issues = append(health.Issues, fmt.Sprintf("port %d has no backends", cfg.Port))
+		return health
+	}
+
+	if health.Up == 0 {
+		health.AllBackendsDown = true
+		health.Issues = append(health.Issues, fmt.Sprintf("all backends of port %d are down", cfg.Port))
+	}
+
+	if health.Down > 0 {
+		health.SomeBackendsDown = true
+
+		if !health.AllBackendsDown {
+			health.Issues = append(health.Issues,
+				fmt.Sprintf("%d of %d backends of port %d are down", health.Down, len(nodes), cfg.Port))
+		}
+	}
+
+	if (cfg.Check == CheckHTTP || cfg.Check == CheckHTTPBody) && cfg.CheckPath == "" {
+		health.Issues = append(health.Issues, fmt.Sprintf("port %d has an HTTP check without a check path", cfg.Port))
+	}
+
+	if health.Modes[ModeAccept] == 0 {
+		health.Issues = append(health.Issues, fmt.Sprintf("port %d has no backends accepting traffic", cfg.Port))
+	}
+
+	return health
+}
@@ -22,6 +22,32 @@ type StatsTraffic struct {
 	Out [][]float64 `json:"out"`
 }
 
+// NodeBalancerStatsSeries represents the decoded time series of a nodebalancer stats data object
+type NodeBalancerStatsSeries struct {
+	Connections TimeSeries
+	TrafficIn   TimeSeries
+	TrafficOut  TimeSeries
+}
+
+// Series decodes the raw stats data into typed time series
+func (d NodeBalancerStatsData) Series() NodeBalancerStatsSeries {
+	return NodeBalancerStatsSeries{
+		Connections: NewTimeSeries(d.Connections),
+		TrafficIn:   NewTimeSeries(d.Traffic.In),
+		TrafficOut:  NewTimeSeries(d.Traffic.Out),
+	}
+}
+
+// Metrics returns every series keyed by a stable metric name, for use with
+// StatsSeries.WriteCSV and StatsSeries.WritePrometheus.
+func (s NodeBalancerStatsSeries) Metrics() StatsSeries {
+	return StatsSeries{
+		"connections": s.Connections,
+		"traffic_in":  s.TrafficIn,
+		"traffic_out": s.TrafficOut,
+	}
+}
+
 // GetNodeBalancerStats gets the template with the provided ID
 func (c *Client) GetNodeBalancerStats(ctx context.Context, nodebalancerID int) (*NodeBalancerStats, error) {
 	e := formatAPIPath("nodebalancers/%d/stats", nodebalancerID)
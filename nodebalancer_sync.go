@@ -0,0 +1,403 @@
+package linodego
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NodeBalancerSyncAction is the kind of change made by SyncNodeBalancer
+type NodeBalancerSyncAction string
+
+// NodeBalancerSyncAction constants are the changes SyncNodeBalancer can make
+const (
+	NodeBalancerSyncCreateConfig NodeBalancerSyncAction = "create_config"
+	NodeBalancerSyncUpdateConfig NodeBalancerSyncAction = "update_config"
+	NodeBalancerSyncDeleteConfig NodeBalancerSyncAction = "delete_config"
+	NodeBalancerSyncCreateNode   NodeBalancerSyncAction = "create_node"
+	NodeBalancerSyncUpdateNode   NodeBalancerSyncAction = "update_node"
+	NodeBalancerSyncDrainNode    NodeBalancerSyncAction = "drain_node"
+	NodeBalancerSyncDeleteNode   NodeBalancerSyncAction = "delete_node"
+)
+
+// defaultNodeBalancerSyncTimeout is used when NodeBalancerSyncOptions.Timeout is not set
+const defaultNodeBalancerSyncTimeout = 5 * time.Minute
+
+// NodeBalancerSyncOptions describes the desired state of a NodeBalancer
+type NodeBalancerSyncOptions struct {
+	// Configs are the desired configs, matched to existing configs by Port.
+	// The Nodes of each config are the desired backends, matched by Address.
+	Configs []NodeBalancerConfigCreateOptions
+
+	// PruneConfigs deletes existing configs whose port is not in Configs
+	PruneConfigs bool
+
+	// DrainPeriod is how long drained backends keep serving pinned connections before they are deleted
+	DrainPeriod time.Duration
+
+	// Timeout is how long to wait for new backends to report as up; defaults to 5 minutes
+	Timeout time.Duration
+
+	// DryRun computes the changes without applying them
+	DryRun bool
+}
+
+// NodeBalancerSyncChange is a single change made by SyncNodeBalancer
+type NodeBalancerSyncChange struct {
+	Action   NodeBalancerSyncAction
+	Port     int
+	ConfigID int
+	NodeID   int
+	Address  string
+}
+
+func (c NodeBalancerSyncChange) String() string {
+	if c.Address != "" {
+		return fmt.Sprintf("%s port %d node %s", c.Action, c.Port, c.Address)
+	}
+
+	return fmt.Sprintf("%s port %d", c.Action, c.Port)
+}
+
+// NodeBalancerSyncResult lists the changes made by SyncNodeBalancer, in order
+type NodeBalancerSyncResult struct {
+	Changes []NodeBalancerSyncChange
+}
+
+type nodeBalancerSyncer struct {
+	client         *Client
+	nodebalancerID int
+	opts           NodeBalancerSyncOptions
+	result         *NodeBalancerSyncResult
+}
+
+// SyncNodeBalancer reconciles the configs and backends of the NodeBalancer with the provided ID
+// against the desired state. Backends are rotated without downtime: new backends are added
+// first, then old backends are rotated out one at a time once the desired backends report as
+// up. Each old backend is drained and only deleted after DrainPeriod has elapsed.
+func (c *Client) SyncNodeBalancer(ctx context.Context, nodebalancerID int, opts NodeBalancerSyncOptions) (*NodeBalancerSyncResult, error) {
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultNodeBalancerSyncTimeout
+	}
+
+	s := &nodeBalancerSyncer{
+		client:         c,
+		nodebalancerID: nodebalancerID,
+		opts:           opts,
+		result:         &NodeBalancerSyncResult{},
+	}
+
+	existing, err := c.ListNodeBalancerConfigs(ctx, nodebalancerID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byPort := make(map[int]NodeBalancerConfig, len(existing))
+	for _, cfg := range existing {
+		byPort[cfg.Port] = cfg
+	}
+
+	desiredPorts := make(map[int]bool, len(opts.Configs))
+
+	for _, desired := range opts.Configs {
+		if desiredPorts[desired.Port] {
+			return nil, fmt.Errorf("port %d is configured more than once", desired.Port)
+		}
+
+		desiredPorts[desired.Port] = true
+
+		current, ok := byPort[desired.Port]
+		if !ok {
+			if err := s.createConfig(ctx, desired); err != nil {
+				return s.result, err
+			}
+
+			continue
+		}
+
+		if err := s.syncConfig(ctx, current, desired); err != nil {
+			return s.result, err
+		}
+	}
+
+	if opts.PruneConfigs {
+		for _, cfg := range existing {
+			if desiredPorts[cfg.Port] {
+				continue
+			}
+
+			if err := s.apply(NodeBalancerSyncChange{
+				Action: NodeBalancerSyncDeleteConfig, Port: cfg.Port, ConfigID: cfg.ID,
+			}, func() error {
+				return c.DeleteNodeBalancerConfig(ctx, nodebalancerID, cfg.ID)
+			}); err != nil {
+				return s.result, err
+			}
+		}
+	}
+
+	return s.result, nil
+}
+
+func (s *nodeBalancerSyncer) apply(change NodeBalancerSyncChange, fn func() error) error {
+	s.result.Changes = append(s.result.Changes, change)
+
+	if s.opts.DryRun {
+		return nil
+	}
+
+	if err := fn(); err != nil {
+		return fmt.Errorf("failed to %s: %w", change, err)
+	}
+
+	return nil
+}
+
+func (s *nodeBalancerSyncer) createConfig(ctx context.Context, desired NodeBalancerConfigCreateOptions) error {
+	return s.apply(NodeBalancerSyncChange{
+		Action: NodeBalancerSyncCreateConfig, Port: desired.Port,
+	}, func() error {
+		_, err := s.client.CreateNodeBalancerConfig(ctx, s.nodebalancerID, desired)
+		return err
+	})
+}
+
+func (s *nodeBalancerSyncer) syncConfig(ctx context.Context, current NodeBalancerConfig, desired NodeBalancerConfigCreateOptions) error {
+	if nodeBalancerConfigNeedsUpdate(current, desired) {
+		updateOpts := NodeBalancerConfigUpdateOptions(desired)
+		updateOpts.Nodes = nil
+
+		if err := s.apply(NodeBalancerSyncChange{
+			Action: NodeBalancerSyncUpdateConfig, Port: current.Port, ConfigID: current.ID,
+		}, func() error {
+			_, err := s.client.UpdateNodeBalancerConfig(ctx, s.nodebalancerID, current.ID, updateOpts)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+
+	nodes, err := s.client.ListNodeBalancerNodes(ctx, s.nodebalancerID, current.ID, nil)
+	if err != nil {
+		return err
+	}
+
+	byAddress := make(map[string]NodeBalancerNode, len(nodes))
+	for _, node := range nodes {
+		byAddress[node.Address] = node
+	}
+
+	desiredAddresses := make(map[string]bool, len(desired.Nodes))
+	desiredIDs := make([]int, 0, len(desired.Nodes))
+
+	for _, node := range desired.Nodes {
+		desiredAddresses[node.Address] = true
+
+		existing, ok := byAddress[node.Address]
+		if !ok {
+			change := NodeBalancerSyncChange{
+				Action: NodeBalancerSyncCreateNode, Port: current.Port, ConfigID: current.ID, Address: node.Address,
+			}
+
+			if err := s.apply(change, func() error {
+				n, err := s.client.CreateNodeBalancerNode(ctx, s.nodebalancerID, current.ID, node)
+				if err != nil {
+					return err
+				}
+
+				desiredIDs = append(desiredIDs, n.ID)
+
+				return nil
+			}); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		desiredIDs = append(desiredIDs, existing.ID)
+
+		if nodeBalancerNodeNeedsUpdate(existing, node) {
+			if err := s.apply(NodeBalancerSyncChange{
+				Action: NodeBalancerSyncUpdateNode, Port: current.Port, ConfigID: current.ID,
+				NodeID: existing.ID, Address: existing.Address,
+			}, func() error {
+				_, err := s.client.UpdateNodeBalancerNode(ctx, s.nodebalancerID, current.ID, existing.ID,
+					NodeBalancerNodeUpdateOptions(node))
+				return err
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	stale := make([]NodeBalancerNode, 0)
+
+	for _, node := range nodes {
+		if !desiredAddresses[node.Address] {
+			stale = append(stale, node)
+		}
+	}
+
+	for _, node := range stale {
+		if err := s.rotateOut(ctx, current, node, desiredIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rotateOut drains and deletes a stale node once the desired nodes report as up, so that
+// capacity is only removed one node at a time while the replacements are serving
+func (s *nodeBalancerSyncer) rotateOut(ctx context.Context, cfg NodeBalancerConfig, node NodeBalancerNode, desiredIDs []int) error {
+	if len(desiredIDs) > 0 && !s.opts.DryRun {
+		if err := s.waitForNodesUp(ctx, cfg.ID, desiredIDs); err != nil {
+			return err
+		}
+	}
+
+	if node.Mode != ModeDrain {
+		if err := s.apply(NodeBalancerSyncChange{
+			Action: NodeBalancerSyncDrainNode, Port: cfg.Port, ConfigID: cfg.ID, NodeID: node.ID, Address: node.Address,
+		}, func() error {
+			_, err := s.client.UpdateNodeBalancerNode(ctx, s.nodebalancerID, cfg.ID, node.ID,
+				NodeBalancerNodeUpdateOptions{Mode: ModeDrain})
+			return err
+		}); err != nil {
+			return err
+		}
+
+		if s.opts.DrainPeriod > 0 && !s.opts.DryRun {
+			select {
+			case <-time.After(s.opts.DrainPeriod):
+			case <-ctx.Done():
+				return fmt.Errorf("Error waiting for NodeBalancer %d config %d node %d to drain: %w",
+					s.nodebalancerID, cfg.ID, node.ID, ctx.Err())
+			}
+		}
+	}
+
+	return s.apply(NodeBalancerSyncChange{
+		Action: NodeBalancerSyncDeleteNode, Port: cfg.Port, ConfigID: cfg.ID, NodeID: node.ID, Address: node.Address,
+	}, func() error {
+		return s.client.DeleteNodeBalancerNode(ctx, s.nodebalancerID, cfg.ID, node.ID)
+	})
+}
+
+// waitForNodesUp waits until the config's NodesStatus and every given node report as up
+func (s *nodeBalancerSyncer) waitForNodesUp(ctx context.Context, configID int, nodeIDs []int) error {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(s.client.pollInterval)
+	defer ticker.Stop()
+
+	pending := make(map[int]bool, len(nodeIDs))
+	for _, id := range nodeIDs {
+		pending[id] = true
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			cfg, err := s.client.GetNodeBalancerConfig(ctx, s.nodebalancerID, configID)
+			if err != nil {
+				return err
+			}
+
+			if cfg.NodesStatus == nil || cfg.NodesStatus.Up < len(nodeIDs) {
+				continue
+			}
+
+			nodes, err := s.client.ListNodeBalancerNodes(ctx, s.nodebalancerID, configID, nil)
+			if err != nil {
+				return err
+			}
+
+			up := 0
+
+			for _, node := range nodes {
+				if pending[node.ID] && strings.EqualFold(node.Status, "up") {
+					up++
+				}
+			}
+
+			if up == len(pending) {
+				return nil
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("Error waiting for NodeBalancer %d config %d nodes to be up: %w", s.nodebalancerID, configID, ctx.Err())
+		}
+	}
+}
+
+func nodeBalancerConfigNeedsUpdate(current NodeBalancerConfig, desired NodeBalancerConfigCreateOptions) bool {
+	differs := func(set bool, equal bool) bool {
+		return set && !equal
+	}
+
+	return differs(desired.Protocol != "", desired.Protocol == current.Protocol) ||
+		differs(desired.ProxyProtocol != "", desired.ProxyProtocol == current.ProxyProtocol) ||
+		differs(desired.Algorithm != "", desired.Algorithm == current.Algorithm) ||
+		differs(desired.Stickiness != "", desired.Stickiness == current.Stickiness) ||
+		differs(desired.Check != "", desired.Check == current.Check) ||
+		differs(desired.CheckInterval != 0, desired.CheckInterval == current.CheckInterval) ||
+		differs(desired.CheckAttempts != 0, desired.CheckAttempts == current.CheckAttempts) ||
+		differs(desired.CheckPath != "", desired.CheckPath == current.CheckPath) ||
+		differs(desired.CheckBody != "", desired.CheckBody == current.CheckBody) ||
+		differs(desired.CheckPassive != nil, desired.CheckPassive != nil && *desired.CheckPassive == current.CheckPassive) ||
+		differs(desired.CheckTimeout != 0, desired.CheckTimeout == current.CheckTimeout) ||
+		differs(desired.CipherSuite != "", desired.CipherSuite == current.CipherSuite) ||
+		nodeBalancerConfigCertDiffers(current, desired)
+}
+
+// nodeBalancerConfigCertDiffers reports whether the desired certificate differs from the one served
+// by the config. The API redacts the current certificate, so the desired leaf certificate's
+// fingerprint is compared with SSLFingerprint instead.
+func nodeBalancerConfigCertDiffers(current NodeBalancerConfig, desired NodeBalancerConfigCreateOptions) bool {
+	if desired.SSLCert == "" {
+		return false
+	}
+
+	for rest := []byte(desired.SSLCert); ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		leaf, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			break
+		}
+
+		return !(&TLSCertificate{Leaf: leaf}).MatchesFingerprint(current.SSLFingerprint)
+	}
+
+	// The desired certificate can't be fingerprinted, so only compare it when the current one is not redacted
+	if current.SSLCert == "" || current.SSLCert == "<REDACTED>" {
+		return false
+	}
+
+	return strings.TrimSpace(desired.SSLCert) != strings.TrimSpace(current.SSLCert)
+}
+
+func nodeBalancerNodeNeedsUpdate(current NodeBalancerNode, desired NodeBalancerNodeCreateOptions) bool {
+	mode := desired.Mode
+	if mode == "" {
+		mode = ModeAccept
+	}
+
+	return (desired.Label != "" && desired.Label != current.Label) ||
+		(desired.Weight != 0 && desired.Weight != current.Weight) ||
+		mode != current.Mode
+}
@@ -0,0 +1,168 @@
+package linodego
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// ObjectStorageBucketContent is a page of the contents of an ObjectStorageBucket
+type ObjectStorageBucketContent struct {
+	Data []ObjectStorageBucketContentData `json:"data"`
+
+	// IsTruncated is set when there are more objects to list; they are listed by
+	// passing NextMarker as the Marker of the next request
+	IsTruncated bool    `json:"is_truncated"`
+	NextMarker  *string `json:"next_marker"`
+}
+
+// ObjectStorageBucketContentData is an object, or a common prefix when listing with a
+// delimiter, in an ObjectStorageBucket
+type ObjectStorageBucketContentData struct {
+	Name         string     `json:"name"`
+	Size         int        `json:"size"`
+	Etag         string     `json:"etag"`
+	LastModified *time.Time `json:"last_modified"`
+	Owner        string     `json:"owner"`
+}
+
+// ObjectStorageBucketListContentsParams are the parameters accepted by ListObjectStorageBucketContents
+type ObjectStorageBucketListContentsParams struct {
+	// Marker is the name of the object to start listing after, as returned in NextMarker
+	Marker *string `query:"marker"`
+
+	// Delimiter groups objects whose names share a prefix up to the delimiter into a single entry
+	Delimiter *string `query:"delimiter"`
+
+	// Prefix limits the listing to objects whose names start with the prefix
+	Prefix *string `query:"prefix"`
+
+	// PageSize is the number of objects to return per page
+	PageSize *int `query:"page_size"`
+}
+
+// IsPrefix reports whether the entry is a common prefix grouping objects that share a name up to the delimiter
+func (d ObjectStorageBucketContentData) IsPrefix(delimiter string) bool {
+	return delimiter != "" && strings.HasSuffix(d.Name, delimiter)
+}
+
+// ListObjectStorageBucketContents lists a page of the objects in the ObjectStorageBucket with the provided label
+func (c *Client) ListObjectStorageBucketContents(
+	ctx context.Context,
+	clusterOrRegionID, label string,
+	params *ObjectStorageBucketListContentsParams,
+) (*ObjectStorageBucketContent, error) {
+	e := formatAPIPath("object-storage/buckets/%s/%s/object-list", clusterOrRegionID, label)
+
+	var queryParams any
+	if params != nil {
+		queryParams = params
+	}
+
+	response, err := doGETRequestWithQuery[ObjectStorageBucketContent](ctx, c, e, queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ObjectStorageBucketContentsIterator iterates over the contents of an ObjectStorageBucket,
+// following NextMarker to fetch pages as needed
+type ObjectStorageBucketContentsIterator struct {
+	client            *Client
+	clusterOrRegionID string
+	label             string
+	params            ObjectStorageBucketListContentsParams
+
+	page    []ObjectStorageBucketContentData
+	current ObjectStorageBucketContentData
+	done    bool
+	err     error
+}
+
+// NewObjectStorageBucketContentsIterator returns an iterator over the contents of the
+// ObjectStorageBucket with the provided label. The params may be nil; a Marker starts
+// the listing after that object.
+//
+//	iter := client.NewObjectStorageBucketContentsIterator("us-east", "my-bucket", nil)
+//	for iter.Next(ctx) {
+//		fmt.Println(iter.Object().Name)
+//	}
+//	if err := iter.Err(); err != nil {
+//		return err
+//	}
+func (c *Client) NewObjectStorageBucketContentsIterator(
+	clusterOrRegionID, label string,
+	params *ObjectStorageBucketListContentsParams,
+) *ObjectStorageBucketContentsIterator {
+	iter := &ObjectStorageBucketContentsIterator{
+		client:            c,
+		clusterOrRegionID: clusterOrRegionID,
+		label:             label,
+	}
+
+	if params != nil {
+		iter.params = *params
+	}
+
+	return iter
+}
+
+// Next advances the iterator to the next object, fetching the next page when needed.
+// It returns false when there are no more objects or an error occurred.
+func (i *ObjectStorageBucketContentsIterator) Next(ctx context.Context) bool {
+	for len(i.page) == 0 {
+		if i.done || i.err != nil {
+			return false
+		}
+
+		content, err := i.client.ListObjectStorageBucketContents(ctx, i.clusterOrRegionID, i.label, &i.params)
+		if err != nil {
+			i.err = err
+			return false
+		}
+
+		i.page = content.Data
+
+		// Stop when the listing is complete or makes no progress
+		if !content.IsTruncated || content.NextMarker == nil || *content.NextMarker == "" ||
+			(i.params.Marker != nil && *i.params.Marker == *content.NextMarker) {
+			i.done = true
+		} else {
+			i.params.Marker = Pointer(*content.NextMarker)
+		}
+	}
+
+	i.current = i.page[0]
+	i.page = i.page[1:]
+
+	return true
+}
+
+// Object returns the object the iterator is positioned at
+func (i *ObjectStorageBucketContentsIterator) Object() ObjectStorageBucketContentData {
+	return i.current
+}
+
+// Err returns the error that stopped the iterator, if any
+func (i *ObjectStorageBucketContentsIterator) Err() error {
+	return i.err
+}
+
+// ListAllObjectStorageBucketContents lists every object in the ObjectStorageBucket with the
+// provided label, following NextMarker across pages
+func (c *Client) ListAllObjectStorageBucketContents(
+	ctx context.Context,
+	clusterOrRegionID, label string,
+	params *ObjectStorageBucketListContentsParams,
+) ([]ObjectStorageBucketContentData, error) {
+	var result []ObjectStorageBucketContentData
+
+	iter := c.NewObjectStorageBucketContentsIterator(clusterOrRegionID, label, params)
+	for iter.Next(ctx) {
+		result = append(result, iter.Object())
+	}
+
+	return result, iter.Err()
+}
@@ -0,0 +1,353 @@
+package linodego
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ObjectStorageACLGranteeType is the kind of grantee of an ObjectStorageACLGrant
+type ObjectStorageACLGranteeType string
+
+// ObjectStorageACLGranteeType constants are the grantee types of S3 access control policies
+const (
+	ACLGranteeCanonicalUser ObjectStorageACLGranteeType = "CanonicalUser"
+	ACLGranteeGroup         ObjectStorageACLGranteeType = "Group"
+	ACLGranteeEmail         ObjectStorageACLGranteeType = "AmazonCustomerByEmail"
+)
+
+// ObjectStorageACLPermission is a permission granted by an ObjectStorageACLGrant
+type ObjectStorageACLPermission string
+
+// ObjectStorageACLPermission constants are the permissions of S3 access control policies
+const (
+	ACLPermissionFullControl ObjectStorageACLPermission = "FULL_CONTROL"
+	ACLPermissionRead        ObjectStorageACLPermission = "READ"
+	ACLPermissionWrite       ObjectStorageACLPermission = "WRITE"
+	ACLPermissionReadACP     ObjectStorageACLPermission = "READ_ACP"
+	ACLPermissionWriteACP    ObjectStorageACLPermission = "WRITE_ACP"
+)
+
+// URIs of the predefined groups that can be granted access
+const (
+	ACLGroupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	ACLGroupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// ObjectStorageACLGrant is a single grant of an access control policy
+type ObjectStorageACLGrant struct {
+	GranteeType ObjectStorageACLGranteeType
+	Permission  ObjectStorageACLPermission
+
+	// ID and DisplayName identify CanonicalUser grantees, URI identifies Group grantees
+	// and Email identifies AmazonCustomerByEmail grantees
+	ID          string
+	DisplayName string
+	URI         string
+	Email       string
+}
+
+// ObjectStorageACLPolicy is a parsed S3 access control policy
+type ObjectStorageACLPolicy struct {
+	OwnerID          string
+	OwnerDisplayName string
+	Grants           []ObjectStorageACLGrant
+}
+
+// ObjectStoragePublicObject is a publicly accessible object found by AuditObjectStoragePublicObjects
+type ObjectStoragePublicObject struct {
+	Name        string
+	ACL         string
+	Permissions []ObjectStorageACLPermission
+}
+
+// ObjectStoragePublicAccessAudit is the result of AuditObjectStoragePublicObjects
+type ObjectStoragePublicAccessAudit struct {
+	BucketACL ObjectStorageACL
+
+	// BucketPublic is set when anyone can list the bucket's objects
+	BucketPublic bool
+
+	PublicObjects []ObjectStoragePublicObject
+	Checked       int
+}
+
+type aclXMLPolicy struct {
+	Owner struct {
+		ID          string `xml:"ID"`
+		DisplayName string `xml:"DisplayName"`
+	} `xml:"Owner"`
+	Grants []struct {
+		Grantee struct {
+			Type        string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+			ID          string `xml:"ID"`
+			DisplayName string `xml:"DisplayName"`
+			URI         string `xml:"URI"`
+			Email       string `xml:"EmailAddress"`
+		} `xml:"Grantee"`
+		Permission string `xml:"Permission"`
+	} `xml:"AccessControlList>Grant"`
+}
+
+// ParseObjectStorageACLXML parses an S3 AccessControlPolicy document, such as
+// ObjectStorageObjectACLConfig.ACLXML, into typed grants
+func ParseObjectStorageACLXML(data string) (*ObjectStorageACLPolicy, error) {
+	var parsed aclXMLPolicy
+	if err := xml.Unmarshal([]byte(data), &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse ACL XML: %w", err)
+	}
+
+	policy := &ObjectStorageACLPolicy{
+		OwnerID:          parsed.Owner.ID,
+		OwnerDisplayName: parsed.Owner.DisplayName,
+		Grants:           make([]ObjectStorageACLGrant, len(parsed.Grants)),
+	}
+
+	for i, grant := range parsed.Grants {
+		policy.Grants[i] = ObjectStorageACLGrant{
+			GranteeType: ObjectStorageACLGranteeType(grant.Grantee.Type),
+			Permission:  ObjectStorageACLPermission(strings.TrimSpace(grant.Permission)),
+			ID:          grant.Grantee.ID,
+			DisplayName: grant.Grantee.DisplayName,
+			URI:         strings.TrimSpace(grant.Grantee.URI),
+			Email:       grant.Grantee.Email,
+		}
+	}
+
+	return policy, nil
+}
+
+// Policy parses the ACLXML of the config into typed grants
+func (a ObjectStorageObjectACLConfig) Policy() (*ObjectStorageACLPolicy, error) {
+	return ParseObjectStorageACLXML(a.ACLXML)
+}
+
+// PublicPermissions returns the permissions the policy grants to everyone
+func (p ObjectStorageACLPolicy) PublicPermissions() []ObjectStorageACLPermission {
+	var result []ObjectStorageACLPermission
+
+	for _, grant := range p.Grants {
+		if grant.GranteeType == ACLGranteeGroup && grant.URI == ACLGroupAllUsers {
+			result = append(result, grant.Permission)
+		}
+	}
+
+	return result
+}
+
+// IsPublicRead reports whether anyone can read the object or bucket the policy applies to
+func (p ObjectStorageACLPolicy) IsPublicRead() bool {
+	for _, permission := range p.PublicPermissions() {
+		if permission == ACLPermissionRead || permission == ACLPermissionFullControl {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AuditObjectStoragePublicObjects checks the ACL of every object in the bucket with the provided
+// label, or of the objects matching params, and reports those anyone can access
+func (c *Client) AuditObjectStoragePublicObjects(
+	ctx context.Context,
+	clusterOrRegionID, label string,
+	params *ObjectStorageBucketListContentsParams,
+) (*ObjectStoragePublicAccessAudit, error) {
+	access, err := c.GetObjectStorageBucketAccess(ctx, clusterOrRegionID, label)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := &ObjectStoragePublicAccessAudit{
+		BucketACL:    access.ACL,
+		BucketPublic: access.ACL == ACLPublicRead || access.ACL == ACLPublicReadWrite,
+	}
+
+	delimiter := ""
+	if params != nil && params.Delimiter != nil {
+		delimiter = *params.Delimiter
+	}
+
+	iter := c.NewObjectStorageBucketContentsIterator(clusterOrRegionID, label, params)
+	for iter.Next(ctx) {
+		object := iter.Object()
+		if object.IsPrefix(delimiter) {
+			continue
+		}
+
+		acl, err := c.GetObjectStorageObjectACLConfig(ctx, clusterOrRegionID, label, object.Name)
+		if err != nil {
+			return audit, fmt.Errorf("failed to get the ACL of %s: %w", object.Name, err)
+		}
+
+		audit.Checked++
+
+		var permissions []ObjectStorageACLPermission
+
+		if acl.ACLXML != "" {
+			policy, err := acl.Policy()
+			if err != nil {
+				return audit, fmt.Errorf("failed to parse the ACL of %s: %w", object.Name, err)
+			}
+
+			permissions = policy.PublicPermissions()
+		} else if acl.ACL == string(ACLPublicRead) || acl.ACL == string(ACLPublicReadWrite) {
+			permissions = []ObjectStorageACLPermission{ACLPermissionRead}
+		}
+
+		if len(permissions) > 0 {
+			audit.PublicObjects = append(audit.PublicObjects, ObjectStoragePublicObject{
+				Name:        object.Name,
+				ACL:         acl.ACL,
+				Permissions: permissions,
+			})
+		}
+	}
+
+	return audit, iter.Err()
+}
+
+// ObjectStorageBucketState is the access configuration of a bucket changed by a static site plan
+type ObjectStorageBucketState struct {
+	ACL         ObjectStorageACL
+	CorsEnabled bool
+	SSL         bool
+}
+
+// ObjectStorageStaticSiteOptions configure PlanObjectStorageStaticSite
+type ObjectStorageStaticSiteOptions struct {
+	// Cert is an optional custom certificate for the bucket's domain
+	Cert *ObjectStorageBucketCertUploadOptions
+
+	// ReplaceCert allows Cert to replace a certificate already on the bucket. A replaced
+	// certificate cannot be restored, so the plan is no longer reversible.
+	ReplaceCert bool
+}
+
+// ObjectStorageStaticSitePlan is a reversible change to the access configuration of a bucket
+type ObjectStorageStaticSitePlan struct {
+	ClusterOrRegionID string
+	Label             string
+
+	Before ObjectStorageBucketState
+	After  ObjectStorageBucketState
+
+	// Cert is uploaded when the plan is applied
+	Cert *ObjectStorageBucketCertUploadOptions
+}
+
+// Changes describes the changes the plan makes
+func (p ObjectStorageStaticSitePlan) Changes() []string {
+	var changes []string
+
+	if p.Before.ACL != p.After.ACL {
+		changes = append(changes, fmt.Sprintf("set ACL %s -> %s", p.Before.ACL, p.After.ACL))
+	}
+
+	if p.Before.CorsEnabled != p.After.CorsEnabled {
+		changes = append(changes, fmt.Sprintf("set CORS %t -> %t", p.Before.CorsEnabled, p.After.CorsEnabled))
+	}
+
+	switch {
+	case p.Cert != nil:
+		changes = append(changes, "upload certificate")
+	case p.Before.SSL && !p.After.SSL:
+		changes = append(changes, "delete certificate")
+	}
+
+	return changes
+}
+
+// Reverse returns the plan that undoes this plan. Plans that replace an existing certificate
+// cannot be reversed, as the private key of the replaced certificate cannot be retrieved.
+func (p ObjectStorageStaticSitePlan) Reverse() (*ObjectStorageStaticSitePlan, error) {
+	if p.Cert != nil && p.Before.SSL {
+		return nil, errors.New("the plan replaces a certificate, which cannot be restored")
+	}
+
+	return &ObjectStorageStaticSitePlan{
+		ClusterOrRegionID: p.ClusterOrRegionID,
+		Label:             p.Label,
+		Before:            p.After,
+		After:             p.Before,
+	}, nil
+}
+
+// PlanObjectStorageStaticSite plans the changes that make the bucket with the provided label
+// serve a static website: a public-read ACL, CORS enabled and optionally a custom certificate.
+// The website configuration itself, such as the index document, is set with the S3 API.
+func (c *Client) PlanObjectStorageStaticSite(
+	ctx context.Context,
+	clusterOrRegionID, label string,
+	opts ObjectStorageStaticSiteOptions,
+) (*ObjectStorageStaticSitePlan, error) {
+	access, err := c.GetObjectStorageBucketAccess(ctx, clusterOrRegionID, label)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := c.GetObjectStorageBucketCert(ctx, clusterOrRegionID, label)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Cert != nil && cert.SSL && !opts.ReplaceCert {
+		return nil, fmt.Errorf("bucket %s already has a certificate; set ReplaceCert to replace it", label)
+	}
+
+	before := ObjectStorageBucketState{ACL: access.ACL, CorsEnabled: access.CorsEnabled, SSL: cert.SSL}
+
+	return &ObjectStorageStaticSitePlan{
+		ClusterOrRegionID: clusterOrRegionID,
+		Label:             label,
+		Before:            before,
+		After: ObjectStorageBucketState{
+			ACL:         ACLPublicRead,
+			CorsEnabled: true,
+			SSL:         cert.SSL || opts.Cert != nil,
+		},
+		Cert: opts.Cert,
+	}, nil
+}
+
+// ApplyObjectStorageStaticSitePlan applies the plan to its bucket
+func (c *Client) ApplyObjectStorageStaticSitePlan(ctx context.Context, plan ObjectStorageStaticSitePlan) error {
+	if plan.Before.ACL != plan.After.ACL || plan.Before.CorsEnabled != plan.After.CorsEnabled {
+		if err := c.UpdateObjectStorageBucketAccess(ctx, plan.ClusterOrRegionID, plan.Label, ObjectStorageBucketUpdateAccessOptions{
+			ACL:         plan.After.ACL,
+			CorsEnabled: Pointer(plan.After.CorsEnabled),
+		}); err != nil {
+			return fmt.Errorf("failed to update the access of bucket %s: %w", plan.Label, err)
+		}
+	}
+
+	switch {
+	case plan.Cert != nil:
+		if _, err := c.UploadObjectStorageBucketCert(ctx, plan.ClusterOrRegionID, plan.Label, *plan.Cert); err != nil {
+			return fmt.Errorf("failed to upload the certificate of bucket %s: %w", plan.Label, err)
+		}
+	case plan.Before.SSL && !plan.After.SSL:
+		if err := c.DeleteObjectStorageBucketCert(ctx, plan.ClusterOrRegionID, plan.Label); err != nil {
+			return fmt.Errorf("failed to delete the certificate of bucket %s: %w", plan.Label, err)
+		}
+	}
+
+	return nil
+}
+
+// EnableObjectStorageStaticSite plans and applies the static website configuration of the
+// bucket in one call. The returned plan can be reversed to restore the previous configuration.
+func (c *Client) EnableObjectStorageStaticSite(
+	ctx context.Context,
+	clusterOrRegionID, label string,
+	opts ObjectStorageStaticSiteOptions,
+) (*ObjectStorageStaticSitePlan, error) {
+	plan, err := c.PlanObjectStorageStaticSite(ctx, clusterOrRegionID, label, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, c.ApplyObjectStorageStaticSitePlan(ctx, *plan)
+}
@@ -0,0 +1,80 @@
+package linodego
+
+import (
+	"testing"
+)
+
+const testACLXML = `<?xml version="1.0" encoding="UTF-8"?>
+<AccessControlPolicy xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Owner><ID>owner-id</ID><DisplayName>owner</DisplayName></Owner>
+  <AccessControlList>
+    <Grant>
+      <Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="CanonicalUser">
+        <ID>owner-id</ID><DisplayName>owner</DisplayName>
+      </Grantee>
+      <Permission>FULL_CONTROL</Permission>
+    </Grant>
+    <Grant>
+      <Grantee xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:type="Group">
+        <URI>http://acs.amazonaws.com/groups/global/AllUsers</URI>
+      </Grantee>
+      <Permission>READ</Permission>
+    </Grant>
+  </AccessControlList>
+</AccessControlPolicy>`
+
+func TestParseObjectStorageACLXML(t *testing.T) {
+	policy, err := ObjectStorageObjectACLConfig{ACLXML: testACLXML}.Policy()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if policy.OwnerID != "owner-id" || len(policy.Grants) != 2 {
+		t.Fatalf("unexpected policy: %+v", policy)
+	}
+
+	owner, public := policy.Grants[0], policy.Grants[1]
+
+	if owner.GranteeType != ACLGranteeCanonicalUser || owner.Permission != ACLPermissionFullControl {
+		t.Errorf("unexpected owner grant: %+v", owner)
+	}
+
+	if public.GranteeType != ACLGranteeGroup || public.URI != ACLGroupAllUsers {
+		t.Errorf("unexpected public grant: %+v", public)
+	}
+
+	if !policy.IsPublicRead() {
+		t.Error("expected the policy to be public")
+	}
+
+	if _, err := ParseObjectStorageACLXML("<AccessControlPolicy"); err == nil {
+		t.Error("expected an error for invalid XML")
+	}
+}
+
+func TestObjectStorageStaticSitePlan_Reverse(t *testing.T) {
+	plan := ObjectStorageStaticSitePlan{
+		Label:  "site",
+		Before: ObjectStorageBucketState{ACL: ACLPrivate},
+		After:  ObjectStorageBucketState{ACL: ACLPublicRead, CorsEnabled: true, SSL: true},
+		Cert:   &ObjectStorageBucketCertUploadOptions{Certificate: "cert", PrivateKey: "key"},
+	}
+
+	if changes := plan.Changes(); len(changes) != 3 {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+
+	reverse, err := plan.Reverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if reverse.After.ACL != ACLPrivate || reverse.Cert != nil || reverse.Changes()[2] != "delete certificate" {
+		t.Errorf("unexpected reverse plan: %+v", reverse)
+	}
+
+	plan.Before.SSL = true
+	if _, err := plan.Reverse(); err == nil {
+		t.Error("expected an error when reversing a replaced certificate")
+	}
+}
@@ -0,0 +1,219 @@
+package linodego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ObjectStorageKeySecretSink receives a replacement key, including its secret, and stores it
+// where the applications using the old key will pick it up, such as a Kubernetes secret
+type ObjectStorageKeySecretSink func(ctx context.Context, key ObjectStorageKey) error
+
+// ObjectStorageKeyRotationOptions configure RotateObjectStorageKey
+type ObjectStorageKeyRotationOptions struct {
+	// Sink receives the replacement key; it is required
+	Sink ObjectStorageKeySecretSink
+
+	// Label of the replacement key; defaults to the label of the old key
+	Label string
+
+	// GracePeriod is how long to wait after the sink succeeds before the old key is deleted
+	GracePeriod time.Duration
+
+	// Confirm is called after the grace period; the old key is kept when it returns false
+	Confirm func(ctx context.Context, key ObjectStorageKey) (bool, error)
+}
+
+// ObjectStorageKeyRotationResult describes a key rotation. The secret of the replacement
+// key is only passed to the sink.
+type ObjectStorageKeyRotationResult struct {
+	OldKeyID      int
+	NewKeyID      int
+	NewAccessKey  string
+	OldKeyDeleted bool
+}
+
+// ObjectStorageKeyAuditEntry is a key reported by AuditObjectStorageKeys
+type ObjectStorageKeyAuditEntry struct {
+	Key ObjectStorageKey
+
+	// Created is when the key was created, or nil when the key predates the account's
+	// event history and its age is unknown
+	Created *time.Time
+
+	// AgeDays is the age of the key in whole days, or -1 when it is unknown
+	AgeDays int
+}
+
+// RotateObjectStorageKey replaces the key with the provided ID with a new key that has the same
+// bucket access and regions. The new key is passed to the sink; if the sink fails, the new key
+// is deleted and the old key is kept. Otherwise the old key is deleted after the grace period,
+// unless the confirmation callback declines.
+func (c *Client) RotateObjectStorageKey(
+	ctx context.Context,
+	keyID int,
+	opts ObjectStorageKeyRotationOptions,
+) (*ObjectStorageKeyRotationResult, error) {
+	if opts.Sink == nil {
+		return nil, errors.New("a secret sink is required to rotate a key")
+	}
+
+	oldKey, err := c.GetObjectStorageKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	newKey, err := c.CreateObjectStorageKey(ctx, oldKey.rotationCreateOptions(opts.Label))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ObjectStorageKeyRotationResult{
+		OldKeyID:     oldKey.ID,
+		NewKeyID:     newKey.ID,
+		NewAccessKey: newKey.AccessKey,
+	}
+
+	if err := opts.Sink(ctx, *newKey); err != nil {
+		// Use a fresh context so the new key is removed even when ctx was cancelled
+		if deleteErr := c.DeleteObjectStorageKey(context.Background(), newKey.ID); deleteErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete replacement key %d: %w", newKey.ID, deleteErr))
+		}
+
+		return nil, fmt.Errorf("failed to store replacement key: %w", err)
+	}
+
+	if opts.GracePeriod > 0 {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(opts.GracePeriod):
+		}
+	}
+
+	if opts.Confirm != nil {
+		confirmed, err := opts.Confirm(ctx, *newKey)
+		if err != nil || !confirmed {
+			return result, err
+		}
+	}
+
+	if err := c.DeleteObjectStorageKey(ctx, oldKey.ID); err != nil && !IsNotFound(err) {
+		return result, fmt.Errorf("failed to delete old key %d: %w", oldKey.ID, err)
+	}
+
+	result.OldKeyDeleted = true
+
+	return result, nil
+}
+
+// rotationCreateOptions returns the options to create a key with the same access as this key
+func (i ObjectStorageKey) rotationCreateOptions(label string) ObjectStorageKeyCreateOptions {
+	if label == "" {
+		label = i.Label
+	}
+
+	opts := ObjectStorageKeyCreateOptions{Label: label}
+
+	if i.Limited && i.BucketAccess != nil {
+		access := make([]ObjectStorageKeyBucketAccess, len(*i.BucketAccess))
+		copy(access, *i.BucketAccess)
+
+		// Cluster is deprecated in favor of Region, so only send one of them
+		for n := range access {
+			if access[n].Region != "" {
+				access[n].Cluster = ""
+			}
+		}
+
+		opts.BucketAccess = &access
+	}
+
+	for _, region := range i.Regions {
+		opts.Regions = append(opts.Regions, region.ID)
+	}
+
+	return opts
+}
+
+// AuditObjectStorageKeys returns the keys created more than maxAgeDays days ago, oldest first.
+// Creation times come from the account's events, so keys that predate the event history are
+// included with an unknown age.
+func (c *Client) AuditObjectStorageKeys(ctx context.Context, maxAgeDays int) ([]ObjectStorageKeyAuditEntry, error) {
+	keys, err := c.ListObjectStorageKeys(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	f := Filter{}
+	f.AddField(Eq, "action", ActionOBJAccessKeyCreate)
+
+	filter, err := f.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := c.ListEvents(ctx, &ListOptions{Filter: string(filter)})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewObjectStorageKeyAudit(keys, events, maxAgeDays, time.Now()), nil
+}
+
+// NewObjectStorageKeyAudit returns the keys created more than maxAgeDays days before now,
+// using the obj_access_key_create events to determine when each key was created
+func NewObjectStorageKeyAudit(keys []ObjectStorageKey, events []Event, maxAgeDays int, now time.Time) []ObjectStorageKeyAuditEntry {
+	created := make(map[string]time.Time)
+
+	for _, event := range events {
+		if event.Action != ActionOBJAccessKeyCreate || event.Entity == nil || event.Created == nil {
+			continue
+		}
+
+		created[objectStorageEventEntityID(event.Entity.ID)] = *event.Created
+	}
+
+	var result []ObjectStorageKeyAuditEntry
+
+	for _, key := range keys {
+		entry := ObjectStorageKeyAuditEntry{Key: key, AgeDays: -1}
+
+		if t, ok := created[strconv.Itoa(key.ID)]; ok {
+			entry.Created = &t
+			entry.AgeDays = int(now.Sub(t).Hours() / 24)
+
+			if entry.AgeDays <= maxAgeDays {
+				continue
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	// Keys of unknown age are the oldest
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Created == nil || result[j].Created == nil {
+			return result[i].Created == nil && result[j].Created != nil
+		}
+
+		return result[i].Created.Before(*result[j].Created)
+	})
+
+	return result
+}
+
+func objectStorageEventEntityID(id any) string {
+	switch id := id.(type) {
+	case float64, float32:
+		return fmt.Sprintf("%.f", id)
+	case int:
+		return strconv.Itoa(id)
+	default:
+		return fmt.Sprintf("%v", id)
+	}
+}
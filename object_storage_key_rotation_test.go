@@ -0,0 +1,52 @@
+package linodego
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewObjectStorageKeyAudit(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -60)
+	recent := now.AddDate(0, 0, -5)
+
+	keys := []ObjectStorageKey{{ID: 1}, {ID: 2}, {ID: 3}}
+	events := []Event{
+		{Action: ActionOBJAccessKeyCreate, Entity: &EventEntity{ID: float64(1)}, Created: &old},
+		{Action: ActionOBJAccessKeyCreate, Entity: &EventEntity{ID: float64(2)}, Created: &recent},
+	}
+
+	audit := NewObjectStorageKeyAudit(keys, events, 30, now)
+
+	if len(audit) != 2 {
+		t.Fatalf("expected 2 keys, got %+v", audit)
+	}
+
+	if audit[0].Key.ID != 3 || audit[0].Created != nil || audit[0].AgeDays != -1 {
+		t.Errorf("expected the key of unknown age first, got %+v", audit[0])
+	}
+
+	if audit[1].Key.ID != 1 || audit[1].AgeDays != 60 {
+		t.Errorf("unexpected entry: %+v", audit[1])
+	}
+}
+
+func TestObjectStorageKey_rotationCreateOptions(t *testing.T) {
+	access := []ObjectStorageKeyBucketAccess{{Cluster: "us-east-1", Region: "us-east", BucketName: "b", Permissions: "read_only"}}
+	key := ObjectStorageKey{
+		Label:        "app",
+		Limited:      true,
+		BucketAccess: &access,
+		Regions:      []ObjectStorageKeyRegion{{ID: "us-east"}},
+	}
+
+	opts := key.rotationCreateOptions("")
+
+	if opts.Label != "app" || len(opts.Regions) != 1 || (*opts.BucketAccess)[0].Cluster != "" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	if access[0].Cluster != "us-east-1" {
+		t.Error("the bucket access of the old key must not be modified")
+	}
+}
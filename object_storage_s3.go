@@ -0,0 +1,263 @@
+package linodego
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Permissions accepted in ObjectStorageKeyBucketAccess
+const (
+	ObjectStorageKeyReadOnly  = "read_only"
+	ObjectStorageKeyReadWrite = "read_write"
+)
+
+// ObjectStorageS3BootstrapOptions configure BootstrapObjectStorageS3
+type ObjectStorageS3BootstrapOptions struct {
+	// Region is the region, or the deprecated cluster ID, of the buckets
+	Region string
+
+	// Buckets are the buckets the key may access
+	Buckets []string
+
+	// Permissions on the buckets; defaults to ObjectStorageKeyReadWrite
+	Permissions string
+
+	// Label of a created key; defaults to a label derived from the buckets
+	Label string
+
+	// Existing are credentials returned by an earlier call to reuse. They are reused when
+	// the key still exists and grants the requested access; otherwise a new key is created.
+	Existing *ObjectStorageS3Config
+}
+
+// ObjectStorageS3Config is everything an S3 client needs to access Object Storage
+type ObjectStorageS3Config struct {
+	// Endpoint is the URL of the S3 API, such as https://us-east-1.linodeobjects.com
+	Endpoint string
+
+	// Region is the region used to sign S3 requests
+	Region string
+
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// KeyID is the ID of the ObjectStorageKey holding the credentials
+	KeyID int
+}
+
+// ObjectStorageS3Credentials mirror the fields of the AWS SDK's aws.Credentials
+type ObjectStorageS3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Source          string
+	CanExpire       bool
+	Expires         time.Time
+}
+
+// Retrieve returns the credentials in the shape of aws.CredentialsProvider, so that they can
+// be adapted without this package depending on the AWS SDK:
+//
+//	provider := aws.CredentialsProviderFunc(func(ctx context.Context) (aws.Credentials, error) {
+//		creds, err := s3Config.Retrieve(ctx)
+//		return aws.Credentials{AccessKeyID: creds.AccessKeyID, SecretAccessKey: creds.SecretAccessKey}, err
+//	})
+func (c ObjectStorageS3Config) Retrieve(_ context.Context) (ObjectStorageS3Credentials, error) {
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return ObjectStorageS3Credentials{}, errors.New("object storage credentials are not set")
+	}
+
+	return ObjectStorageS3Credentials{
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		Source:          "LinodeObjectStorageKey",
+	}, nil
+}
+
+// Environ returns the configuration as the environment variables read by the AWS SDKs and
+// CLI, in the "key=value" form used by os/exec
+func (c ObjectStorageS3Config) Environ() []string {
+	return []string{
+		"AWS_ACCESS_KEY_ID=" + c.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY=" + c.SecretAccessKey,
+		"AWS_REGION=" + c.Region,
+		"AWS_ENDPOINT_URL=" + c.Endpoint,
+	}
+}
+
+// BootstrapObjectStorageS3 returns the endpoint and credentials of a limited key scoped to the
+// requested buckets, creating the key unless existing credentials can be reused. The returned
+// cleanup function deletes a key created by this call and does nothing for reused keys.
+//
+//nolint:gocognit
+func (c *Client) BootstrapObjectStorageS3(
+	ctx context.Context,
+	opts ObjectStorageS3BootstrapOptions,
+) (*ObjectStorageS3Config, func(context.Context) error, error) {
+	noCleanup := func(context.Context) error { return nil }
+
+	if opts.Region == "" || len(opts.Buckets) == 0 {
+		return nil, nil, errors.New("a region and at least one bucket are required")
+	}
+
+	if opts.Permissions == "" {
+		opts.Permissions = ObjectStorageKeyReadWrite
+	}
+
+	if opts.Existing != nil {
+		key, err := c.GetObjectStorageKey(ctx, opts.Existing.KeyID)
+		if err != nil && !IsNotFound(err) {
+			return nil, nil, err
+		}
+
+		if err == nil && key.AccessKey == opts.Existing.AccessKeyID && objectStorageKeyGrants(key, opts) {
+			existing := *opts.Existing
+			return &existing, noCleanup, nil
+		}
+	}
+
+	label := opts.Label
+	if label == "" {
+		label = truncateString("s3-"+strings.Join(opts.Buckets, "-"), 50)
+	}
+
+	isCluster, err := c.isObjectStorageClusterID(ctx, opts.Region)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	access := make([]ObjectStorageKeyBucketAccess, len(opts.Buckets))
+	for i, bucket := range opts.Buckets {
+		access[i] = ObjectStorageKeyBucketAccess{
+			BucketName:  bucket,
+			Permissions: opts.Permissions,
+		}
+
+		// Keys only accept a cluster ID in the deprecated Cluster field
+		if isCluster {
+			access[i].Cluster = opts.Region
+		} else {
+			access[i].Region = opts.Region
+		}
+	}
+
+	key, err := c.CreateObjectStorageKey(ctx, ObjectStorageKeyCreateOptions{
+		Label:        label,
+		BucketAccess: &access,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cleanup := func(ctx context.Context) error {
+		if err := c.DeleteObjectStorageKey(ctx, key.ID); err != nil && !IsNotFound(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	endpoint, err := c.objectStorageS3Endpoint(ctx, key, opts.Region)
+	if err != nil {
+		return nil, nil, errors.Join(err, cleanup(ctx))
+	}
+
+	return &ObjectStorageS3Config{
+		Endpoint:        endpoint,
+		Region:          objectStorageS3SigningRegion(endpoint, opts.Region),
+		AccessKeyID:     key.AccessKey,
+		SecretAccessKey: key.SecretKey,
+		KeyID:           key.ID,
+	}, cleanup, nil
+}
+
+// isObjectStorageClusterID reports whether id is the ID of an Object Storage cluster rather than a region
+func (c *Client) isObjectStorageClusterID(ctx context.Context, id string) (bool, error) {
+	clusters, err := c.ListObjectStorageClusters(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	for _, cluster := range clusters {
+		if cluster.ID == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// objectStorageS3Endpoint returns the S3 endpoint URL of the region, preferring the endpoint
+// reported with the key over the domain of the region's cluster
+func (c *Client) objectStorageS3Endpoint(ctx context.Context, key *ObjectStorageKey, region string) (string, error) {
+	for _, keyRegion := range key.Regions {
+		if keyRegion.ID == region && keyRegion.S3Endpoint != "" {
+			return objectStorageEndpointURL(keyRegion.S3Endpoint), nil
+		}
+	}
+
+	clusters, err := c.ListObjectStorageClusters(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for _, cluster := range clusters {
+		if cluster.ID == region || cluster.Region == region {
+			return objectStorageEndpointURL(cluster.Domain), nil
+		}
+	}
+
+	return "", fmt.Errorf("no Object Storage endpoint found for region %s", region)
+}
+
+// objectStorageKeyGrants reports whether the key is limited and grants the requested access
+// to every bucket. Unlimited keys are never reused, as they are not scoped to the buckets.
+func objectStorageKeyGrants(key *ObjectStorageKey, opts ObjectStorageS3BootstrapOptions) bool {
+	if !key.Limited || key.BucketAccess == nil {
+		return false
+	}
+
+	for _, bucket := range opts.Buckets {
+		granted := false
+
+		for _, access := range *key.BucketAccess {
+			if access.BucketName != bucket || (access.Region != opts.Region && access.Cluster != opts.Region) {
+				continue
+			}
+
+			if access.Permissions == opts.Permissions || access.Permissions == ObjectStorageKeyReadWrite {
+				granted = true
+				break
+			}
+		}
+
+		if !granted {
+			return false
+		}
+	}
+
+	return true
+}
+
+func objectStorageEndpointURL(host string) string {
+	if strings.HasPrefix(host, "https://") || strings.HasPrefix(host, "http://") {
+		return strings.TrimSuffix(host, "/")
+	}
+
+	return "https://" + strings.TrimSuffix(host, "/")
+}
+
+// objectStorageS3SigningRegion returns the region to sign requests for, which is the first
+// label of the endpoint's hostname, such as us-east-1 for us-east-1.linodeobjects.com
+func objectStorageS3SigningRegion(endpoint, fallback string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://")
+
+	if i := strings.Index(host, "."); i > 0 {
+		return host[:i]
+	}
+
+	return fallback
+}
@@ -0,0 +1,429 @@
+package linodego
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec // Object Storage ETags are MD5 digests
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults used when the corresponding ObjectStorageTransferManager fields are not set
+const (
+	defaultObjectStorageTransferRetries    = 3
+	defaultObjectStorageTransferRetryDelay = time.Second
+)
+
+// ErrObjectStorageChecksumMismatch is returned when the checksum of a transferred object
+// does not match the expected checksum
+var ErrObjectStorageChecksumMismatch = errors.New("object checksum mismatch")
+
+// ObjectStorageTransferProgress is called as bytes are transferred. The total is -1 when
+// the size of the object is not known.
+type ObjectStorageTransferProgress func(transferred, total int64)
+
+// ObjectStorageTransferOptions configure a single upload or download
+type ObjectStorageTransferOptions struct {
+	// ContentType and ContentDisposition are stored with uploaded objects and override the
+	// headers returned with downloaded objects. The API only applies ContentDisposition to
+	// presigned download URLs.
+	ContentType        string
+	ContentDisposition string
+
+	// ExpiresIn is the lifetime of the presigned URL in seconds
+	ExpiresIn *int
+
+	// Progress is called as bytes are transferred
+	Progress ObjectStorageTransferProgress
+
+	// MD5 is the expected hex MD5 digest of the object. When empty, the digest is compared
+	// with the ETag returned by Object Storage when the ETag is an MD5 digest.
+	MD5 string
+}
+
+// ObjectStorageTransferResult describes a completed upload or download
+type ObjectStorageTransferResult struct {
+	Name     string
+	Size     int64
+	ETag     string
+	MD5      string
+	Attempts int
+}
+
+// ObjectStorageTransferManager moves object data to and from an Object Storage bucket through
+// presigned URLs minted with the Linode API, so no Object Storage keys are required
+type ObjectStorageTransferManager struct {
+	client            *Client
+	clusterOrRegionID string
+	label             string
+
+	// HTTPClient sends requests to the presigned URLs; defaults to http.DefaultClient.
+	// It must not add Linode API credentials to requests.
+	HTTPClient *http.Client
+
+	// Retries is the number of times a transfer is retried after a transient failure; defaults to 3
+	Retries int
+
+	// RetryDelay is the delay before the first retry, doubling with each retry; defaults to 1 second
+	RetryDelay time.Duration
+}
+
+// NewObjectStorageTransferManager returns a transfer manager for the bucket with the provided label
+func (c *Client) NewObjectStorageTransferManager(clusterOrRegionID, label string) *ObjectStorageTransferManager {
+	return &ObjectStorageTransferManager{
+		client:            c,
+		clusterOrRegionID: clusterOrRegionID,
+		label:             label,
+		Retries:           defaultObjectStorageTransferRetries,
+		RetryDelay:        defaultObjectStorageTransferRetryDelay,
+	}
+}
+
+// Upload uploads the body to the object with the provided name. Bodies that implement
+// io.Seeker are streamed from their current offset and rewound for retries; other bodies
+// are buffered in memory first, as Object Storage requires the length of the object.
+func (m *ObjectStorageTransferManager) Upload(
+	ctx context.Context,
+	name string,
+	body io.Reader,
+	opts *ObjectStorageTransferOptions,
+) (*ObjectStorageTransferResult, error) {
+	if opts == nil {
+		opts = &ObjectStorageTransferOptions{}
+	}
+
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+
+		seeker = bytes.NewReader(data)
+	}
+
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ObjectStorageTransferResult{Name: name, Size: end - start}
+
+	err = m.retry(ctx, result, func() error {
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+
+		url, err := m.presign(ctx, name, http.MethodPut, opts)
+		if err != nil {
+			return err
+		}
+
+		hasher := md5.New() //nolint:gosec
+		reader := &objectStorageProgressReader{
+			reader: io.TeeReader(io.LimitReader(seeker, result.Size), hasher),
+			total:  result.Size,
+			report: opts.Progress,
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, reader)
+		if err != nil {
+			return err
+		}
+
+		req.ContentLength = result.Size
+
+		if opts.ContentType != "" {
+			req.Header.Set("Content-Type", opts.ContentType)
+		}
+
+		if opts.ContentDisposition != "" {
+			req.Header.Set("Content-Disposition", opts.ContentDisposition)
+		}
+
+		resp, err := m.httpClient().Do(req)
+		if err != nil {
+			return &objectStorageTransientError{err}
+		}
+
+		defer resp.Body.Close()
+
+		if err := checkObjectStorageTransferResponse(resp); err != nil {
+			return err
+		}
+
+		result.ETag = strings.Trim(resp.Header.Get("ETag"), `"`)
+		result.MD5 = hex.EncodeToString(hasher.Sum(nil))
+
+		return verifyObjectStorageChecksum(result, opts.MD5)
+	})
+
+	return result, err
+}
+
+// Download writes the object with the provided name to the writer. Retries resume from the
+// last byte written with a range request, so the writer never receives data twice.
+func (m *ObjectStorageTransferManager) Download(
+	ctx context.Context,
+	name string,
+	w io.Writer,
+	opts *ObjectStorageTransferOptions,
+) (*ObjectStorageTransferResult, error) {
+	if opts == nil {
+		opts = &ObjectStorageTransferOptions{}
+	}
+
+	result := &ObjectStorageTransferResult{Name: name}
+	hasher := md5.New() //nolint:gosec
+	total := int64(-1)
+
+	err := m.retry(ctx, result, func() error {
+		url, err := m.presign(ctx, name, http.MethodGet, opts)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+
+		if result.Size > 0 {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", result.Size))
+		}
+
+		resp, err := m.httpClient().Do(req)
+		if err != nil {
+			return &objectStorageTransientError{err}
+		}
+
+		defer resp.Body.Close()
+
+		if err := checkObjectStorageTransferResponse(resp); err != nil {
+			return err
+		}
+
+		body := io.Reader(resp.Body)
+
+		switch {
+		case resp.StatusCode == http.StatusPartialContent:
+			total = objectStorageContentRangeTotal(resp.Header.Get("Content-Range"), total)
+		case result.Size > 0:
+			// The range was ignored, so skip the bytes that were already written
+			if _, err := io.CopyN(io.Discard, body, result.Size); err != nil {
+				return &objectStorageTransientError{err}
+			}
+
+			total = resp.ContentLength
+		default:
+			total = resp.ContentLength
+		}
+
+		etag := strings.Trim(resp.Header.Get("ETag"), `"`)
+		if result.ETag != "" && etag != "" && etag != result.ETag {
+			return fmt.Errorf("object %s changed during the download", name)
+		}
+
+		if etag != "" {
+			result.ETag = etag
+		}
+
+		reader := &objectStorageProgressReader{
+			reader:      body,
+			transferred: result.Size,
+			total:       total,
+			report:      opts.Progress,
+		}
+
+		n, err := io.Copy(io.MultiWriter(objectStorageWriter{w}, hasher), reader)
+		result.Size += n
+
+		if err != nil {
+			var writeErr *objectStorageWriteError
+			if errors.As(err, &writeErr) {
+				return writeErr.err
+			}
+
+			return &objectStorageTransientError{err}
+		}
+
+		result.MD5 = hex.EncodeToString(hasher.Sum(nil))
+
+		return verifyObjectStorageChecksum(result, opts.MD5)
+	})
+
+	return result, err
+}
+
+func (m *ObjectStorageTransferManager) presign(ctx context.Context, name, method string, opts *ObjectStorageTransferOptions) (string, error) {
+	createOpts := ObjectStorageObjectURLCreateOptions{
+		Name:        name,
+		Method:      method,
+		ContentType: opts.ContentType,
+		ExpiresIn:   opts.ExpiresIn,
+	}
+
+	// content_disposition is only honored for GET
+	if method == http.MethodGet {
+		createOpts.ContentDisposition = opts.ContentDisposition
+	}
+
+	url, err := m.client.CreateObjectStorageObjectURL(ctx, m.clusterOrRegionID, m.label, createOpts)
+	if err != nil {
+		return "", err
+	}
+
+	return url.URL, nil
+}
+
+func (m *ObjectStorageTransferManager) httpClient() *http.Client {
+	if m.HTTPClient != nil {
+		return m.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+// retry runs the attempt until it succeeds, fails with an error that is not transient or
+// runs out of retries
+func (m *ObjectStorageTransferManager) retry(
+	ctx context.Context,
+	result *ObjectStorageTransferResult,
+	attempt func() error,
+) error {
+	delay := m.RetryDelay
+
+	for {
+		result.Attempts++
+
+		err := attempt()
+
+		var transient *objectStorageTransientError
+		if err == nil || !errors.As(err, &transient) || result.Attempts > m.Retries {
+			if transient != nil {
+				return transient.err
+			}
+
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+}
+
+// objectStorageTransientError marks failures that are worth retrying
+type objectStorageTransientError struct {
+	err error
+}
+
+func (e *objectStorageTransientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *objectStorageTransientError) Unwrap() error {
+	return e.err
+}
+
+// objectStorageWriteError marks failures of the caller's writer, which are never retried
+type objectStorageWriteError struct {
+	err error
+}
+
+func (e *objectStorageWriteError) Error() string {
+	return e.err.Error()
+}
+
+// objectStorageWriter wraps the caller's writer to tell its errors apart from read errors
+type objectStorageWriter struct {
+	w io.Writer
+}
+
+func (w objectStorageWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if err != nil {
+		return n, &objectStorageWriteError{err}
+	}
+
+	return n, nil
+}
+
+func checkObjectStorageTransferResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err := fmt.Errorf("object storage transfer failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusRequestTimeout {
+		return &objectStorageTransientError{err}
+	}
+
+	return err
+}
+
+// verifyObjectStorageChecksum compares the digest of the transfer with the expected digest,
+// or with the ETag when it is a plain MD5 digest rather than a multipart ETag
+func verifyObjectStorageChecksum(result *ObjectStorageTransferResult, expected string) error {
+	if expected == "" && len(result.ETag) == md5.Size*2 && !strings.Contains(result.ETag, "-") {
+		expected = result.ETag
+	}
+
+	if expected != "" && !strings.EqualFold(expected, result.MD5) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrObjectStorageChecksumMismatch, expected, result.MD5)
+	}
+
+	return nil
+}
+
+// objectStorageContentRangeTotal returns the total size from a Content-Range header such as
+// "bytes 100-199/200", or the fallback when it is not known
+func objectStorageContentRangeTotal(contentRange string, fallback int64) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return fallback
+	}
+
+	total, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return fallback
+	}
+
+	return total
+}
+
+// objectStorageProgressReader reports the progress of a transfer as it is read
+type objectStorageProgressReader struct {
+	reader      io.Reader
+	transferred int64
+	total       int64
+	report      ObjectStorageTransferProgress
+}
+
+func (r *objectStorageProgressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.report != nil {
+		r.transferred += int64(n)
+		r.report(r.transferred, r.total)
+	}
+
+	return n, err
+}
@@ -0,0 +1,188 @@
+package linodego
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ObjectStorageUsageGroupBy is how an ObjectStorageUsageReport groups buckets
+type ObjectStorageUsageGroupBy string
+
+// ObjectStorageUsageGroupBy constants are the groupings supported by ObjectStorageUsageReport
+const (
+	ObjectStorageUsageByRegion  ObjectStorageUsageGroupBy = "region"
+	ObjectStorageUsageByCluster ObjectStorageUsageGroupBy = "cluster"
+	ObjectStorageUsageByPrefix  ObjectStorageUsageGroupBy = "prefix"
+	ObjectStorageUsageByLabel   ObjectStorageUsageGroupBy = "label"
+)
+
+// defaultObjectStorageUsagePrefixDelimiter is used when ObjectStorageUsageOptions.PrefixDelimiter is not set
+const defaultObjectStorageUsagePrefixDelimiter = "-"
+
+// ObjectStorageUsageOptions configure GetObjectStorageUsageReport
+type ObjectStorageUsageOptions struct {
+	// GroupBy defaults to ObjectStorageUsageByRegion
+	GroupBy ObjectStorageUsageGroupBy
+
+	// PrefixDelimiter ends the prefix of a bucket label when grouping by prefix, so that
+	// "billing-logs" and "billing-data" share the prefix "billing"; defaults to "-"
+	PrefixDelimiter string
+}
+
+// ObjectStorageUsageGroup is the usage of the buckets sharing a grouping key
+type ObjectStorageUsageGroup struct {
+	Key     string `json:"key"`
+	Buckets int    `json:"buckets"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+}
+
+// ObjectStorageUsageReport is the usage of every bucket on the account, grouped for chargeback
+type ObjectStorageUsageReport struct {
+	GeneratedAt time.Time                 `json:"generated_at"`
+	GroupBy     ObjectStorageUsageGroupBy `json:"group_by"`
+
+	TotalBuckets int   `json:"total_buckets"`
+	TotalObjects int64 `json:"total_objects"`
+	TotalSize    int64 `json:"total_size"`
+
+	// TransferUsed is the account's outbound transfer reported by GetObjectStorageTransfer.
+	// The API doesn't break transfer down by bucket, so it is not attributed to groups.
+	TransferUsed int64 `json:"transfer_used"`
+
+	Groups []ObjectStorageUsageGroup `json:"groups"`
+}
+
+// GetObjectStorageUsageReport returns the usage of all buckets across clusters and regions,
+// grouped as configured, along with the account's outbound transfer
+func (c *Client) GetObjectStorageUsageReport(ctx context.Context, opts ObjectStorageUsageOptions) (*ObjectStorageUsageReport, error) {
+	buckets, err := c.ListObjectStorageBuckets(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := c.GetObjectStorageTransfer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewObjectStorageUsageReport(buckets, int64(transfer.AmmountUsed), opts, time.Now())
+}
+
+// NewObjectStorageUsageReport groups the usage of the provided buckets. The transfer is the
+// account's outbound transfer in bytes, which is reported for the account as a whole.
+func NewObjectStorageUsageReport(
+	buckets []ObjectStorageBucket,
+	transfer int64,
+	opts ObjectStorageUsageOptions,
+	now time.Time,
+) (*ObjectStorageUsageReport, error) {
+	if opts.GroupBy == "" {
+		opts.GroupBy = ObjectStorageUsageByRegion
+	}
+
+	if opts.PrefixDelimiter == "" {
+		opts.PrefixDelimiter = defaultObjectStorageUsagePrefixDelimiter
+	}
+
+	report := &ObjectStorageUsageReport{
+		GeneratedAt:  now.UTC(),
+		GroupBy:      opts.GroupBy,
+		TransferUsed: transfer,
+		Groups:       make([]ObjectStorageUsageGroup, 0),
+	}
+
+	groups := make(map[string]*ObjectStorageUsageGroup)
+
+	for _, bucket := range buckets {
+		key, err := objectStorageUsageKey(bucket, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		group, ok := groups[key]
+		if !ok {
+			group = &ObjectStorageUsageGroup{Key: key}
+			groups[key] = group
+		}
+
+		group.Buckets++
+		group.Objects += int64(bucket.Objects)
+		group.Size += int64(bucket.Size)
+
+		report.TotalBuckets++
+		report.TotalObjects += int64(bucket.Objects)
+		report.TotalSize += int64(bucket.Size)
+	}
+
+	for _, group := range groups {
+		report.Groups = append(report.Groups, *group)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		return report.Groups[i].Key < report.Groups[j].Key
+	})
+
+	return report, nil
+}
+
+func objectStorageUsageKey(bucket ObjectStorageBucket, opts ObjectStorageUsageOptions) (string, error) {
+	switch opts.GroupBy {
+	case ObjectStorageUsageByRegion:
+		if bucket.Region != "" {
+			return bucket.Region, nil
+		}
+
+		// Older responses only include the cluster, which can't be mapped to a region reliably
+		return bucket.Cluster, nil
+	case ObjectStorageUsageByCluster:
+		return bucket.Cluster, nil
+	case ObjectStorageUsageByPrefix:
+		prefix, _, _ := strings.Cut(bucket.Label, opts.PrefixDelimiter)
+		return prefix, nil
+	case ObjectStorageUsageByLabel:
+		return bucket.Label, nil
+	}
+
+	return "", fmt.Errorf("unsupported usage grouping %q", opts.GroupBy)
+}
+
+// JSON returns the report as indented JSON
+func (r ObjectStorageUsageReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// WriteCSV writes the groups of the report to w as CSV with the columns group_by, key,
+// buckets, objects and size_bytes
+func (r ObjectStorageUsageReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"group_by", "key", "buckets", "objects", "size_bytes"}); err != nil {
+		return err
+	}
+
+	for _, group := range r.Groups {
+		record := []string{
+			string(r.GroupBy),
+			group.Key,
+			strconv.Itoa(group.Buckets),
+			strconv.FormatInt(group.Objects, 10),
+			strconv.FormatInt(group.Size, 10),
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
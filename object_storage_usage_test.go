@@ -0,0 +1,68 @@
+package linodego
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewObjectStorageUsageReport(t *testing.T) {
+	buckets := []ObjectStorageBucket{
+		{Label: "billing-logs", Region: "us-east", Cluster: "us-east-1", Size: 300, Objects: 3},
+		{Label: "billing-data", Cluster: "us-east-1", Size: 100, Objects: 1},
+		{Label: "web", Region: "eu-central", Cluster: "eu-central-1", Size: 600, Objects: 6},
+	}
+
+	report, err := NewObjectStorageUsageReport(buckets, 1000, ObjectStorageUsageOptions{}, time.Unix(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The bucket without a region is grouped under its cluster rather than a guessed region
+	if report.TotalBuckets != 3 || report.TotalSize != 1000 || len(report.Groups) != 3 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if east := report.Groups[1]; east.Key != "us-east" || east.Buckets != 1 || east.Size != 300 {
+		t.Errorf("unexpected group: %+v", east)
+	}
+
+	if cluster := report.Groups[2]; cluster.Key != "us-east-1" || cluster.Size != 100 {
+		t.Errorf("unexpected cluster group: %+v", cluster)
+	}
+
+	byPrefix, err := NewObjectStorageUsageReport(buckets, 0, ObjectStorageUsageOptions{GroupBy: ObjectStorageUsageByPrefix}, time.Unix(0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if byPrefix.Groups[0].Key != "billing" || byPrefix.Groups[0].Objects != 4 {
+		t.Errorf("unexpected prefix group: %+v", byPrefix.Groups[0])
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "group_by,key,buckets,objects,size_bytes" || lines[2] != "region,us-east,1,3,300" {
+		t.Errorf("unexpected CSV: %q", buf.String())
+	}
+
+	data, err := report.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded ObjectStorageUsageReport
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.TransferUsed != 1000 {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	if _, err := NewObjectStorageUsageReport(buckets, 0, ObjectStorageUsageOptions{GroupBy: "owner"}, time.Now()); err == nil {
+		t.Error("expected an error for an unsupported grouping")
+	}
+}
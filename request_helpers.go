@@ -115,6 +115,35 @@ func doGETRequest[T any](
 	return r.Result().(*T), nil
 }
 
+// doGETRequestWithQuery runs a GET request using the given client and API endpoint,
+// with the query parameters taken from the `query` tags of the optional params struct.
+func doGETRequestWithQuery[T any](
+	ctx context.Context,
+	client *Client,
+	endpoint string,
+	params any,
+) (*T, error) {
+	var resultType T
+
+	req := client.R(ctx).SetResult(&resultType)
+
+	if params != nil {
+		queryParams, err := flattenQueryStruct(params)
+		if err != nil {
+			return nil, err
+		}
+
+		req.SetQueryParams(queryParams)
+	}
+
+	r, err := coupleAPIErrors(req.Get(endpoint))
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Result().(*T), nil
+}
+
 // doPOSTRequest runs a PUT request using the given client, API endpoint,
 // and options/body.
 func doPOSTRequest[T, O any](
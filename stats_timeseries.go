@@ -0,0 +1,336 @@
+package linodego
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeSeriesPoint represents a single decoded stats sample
+type TimeSeriesPoint struct {
+	Time  time.Time
+	Value float64
+}
+
+// TimeSeries represents a list of decoded stats samples ordered by time
+type TimeSeries []TimeSeriesPoint
+
+// NewTimeSeries decodes the raw [timestamp, value] pairs returned by the
+// Linode stats endpoints into a TimeSeries. Timestamps are expressed in
+// milliseconds since the Unix epoch. Malformed pairs are skipped.
+func NewTimeSeries(raw [][]float64) TimeSeries {
+	result := make(TimeSeries, 0, len(raw))
+
+	for _, pair := range raw {
+		if len(pair) < 2 || math.IsNaN(pair[0]) {
+			continue
+		}
+
+		result = append(result, TimeSeriesPoint{
+			Time:  time.UnixMilli(int64(pair[0])).UTC(),
+			Value: pair[1],
+		})
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result
+}
+
+// Raw encodes the TimeSeries back into the [timestamp, value] pairs
+// used by the Linode API.
+func (ts TimeSeries) Raw() [][]float64 {
+	result := make([][]float64, len(ts))
+
+	for i, p := range ts {
+		result[i] = []float64{float64(p.Time.UnixMilli()), p.Value}
+	}
+
+	return result
+}
+
+// Values returns the values of every point in the TimeSeries
+func (ts TimeSeries) Values() []float64 {
+	result := make([]float64, len(ts))
+
+	for i, p := range ts {
+		result[i] = p.Value
+	}
+
+	return result
+}
+
+// Between returns the points of the TimeSeries within the inclusive
+// range [start, end]. A zero start or end leaves that side unbounded.
+func (ts TimeSeries) Between(start, end time.Time) TimeSeries {
+	result := make(TimeSeries, 0, len(ts))
+
+	for _, p := range ts {
+		if !start.IsZero() && p.Time.Before(start) {
+			continue
+		}
+
+		if !end.IsZero() && p.Time.After(end) {
+			continue
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// Last returns the most recent point of the TimeSeries
+func (ts TimeSeries) Last() (TimeSeriesPoint, bool) {
+	if len(ts) == 0 {
+		return TimeSeriesPoint{}, false
+	}
+
+	return ts[len(ts)-1], true
+}
+
+// Sum returns the sum of all values in the TimeSeries
+func (ts TimeSeries) Sum() float64 {
+	var sum float64
+
+	for _, p := range ts {
+		sum += p.Value
+	}
+
+	return sum
+}
+
+// Avg returns the mean value of the TimeSeries, or 0 if it is empty
+func (ts TimeSeries) Avg() float64 {
+	if len(ts) == 0 {
+		return 0
+	}
+
+	return ts.Sum() / float64(len(ts))
+}
+
+// Max returns the largest value of the TimeSeries, or 0 if it is empty
+func (ts TimeSeries) Max() float64 {
+	if len(ts) == 0 {
+		return 0
+	}
+
+	result := ts[0].Value
+
+	for _, p := range ts[1:] {
+		result = math.Max(result, p.Value)
+	}
+
+	return result
+}
+
+// Min returns the smallest value of the TimeSeries, or 0 if it is empty
+func (ts TimeSeries) Min() float64 {
+	if len(ts) == 0 {
+		return 0
+	}
+
+	result := ts[0].Value
+
+	for _, p := range ts[1:] {
+		result = math.Min(result, p.Value)
+	}
+
+	return result
+}
+
+// Percentile returns the p-th percentile (0-100) of the TimeSeries values
+// using linear interpolation between the closest ranks.
+func (ts TimeSeries) Percentile(p float64) float64 {
+	if len(ts) == 0 {
+		return 0
+	}
+
+	values := ts.Values()
+	sort.Float64s(values)
+
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(values)-1)
+
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	if lower == upper {
+		return values[lower]
+	}
+
+	return values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+}
+
+// P95 returns the 95th percentile of the TimeSeries values
+func (ts TimeSeries) P95() float64 {
+	return ts.Percentile(95)
+}
+
+// Rate returns the per-second rate of change of the TimeSeries. Each output
+// point is computed between a sample and the oldest sample no further than
+// window before it. Samples without an earlier neighbour in the window are
+// omitted.
+func (ts TimeSeries) Rate(window time.Duration) TimeSeries {
+	result := make(TimeSeries, 0, len(ts))
+
+	start := 0
+
+	for i, p := range ts {
+		for start < i && p.Time.Sub(ts[start].Time) > window {
+			start++
+		}
+
+		if start == i {
+			continue
+		}
+
+		elapsed := p.Time.Sub(ts[start].Time).Seconds()
+		if elapsed <= 0 {
+			continue
+		}
+
+		result = append(result, TimeSeriesPoint{
+			Time:  p.Time,
+			Value: (p.Value - ts[start].Value) / elapsed,
+		})
+	}
+
+	return result
+}
+
+// MergeTimeSeries combines the given TimeSeries into a single continuous
+// TimeSeries ordered by time. When multiple series contain a sample at the
+// same timestamp, the value from the later series is kept.
+func MergeTimeSeries(series ...TimeSeries) TimeSeries {
+	byTime := make(map[int64]float64)
+
+	for _, ts := range series {
+		for _, p := range ts {
+			byTime[p.Time.UnixMilli()] = p.Value
+		}
+	}
+
+	result := make(TimeSeries, 0, len(byTime))
+
+	for ts, value := range byTime {
+		result = append(result, TimeSeriesPoint{
+			Time:  time.UnixMilli(ts).UTC(),
+			Value: value,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Time.Before(result[j].Time)
+	})
+
+	return result
+}
+
+// StatsSeries is a set of named TimeSeries that can be exported together
+type StatsSeries map[string]TimeSeries
+
+func (s StatsSeries) sortedNames() []string {
+	names := make([]string, 0, len(s))
+
+	for name := range s {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// WriteCSV writes the StatsSeries to w as CSV with the columns
+// metric, timestamp (RFC 3339) and value.
+func (s StatsSeries) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"metric", "timestamp", "value"}); err != nil {
+		return err
+	}
+
+	for _, name := range s.sortedNames() {
+		for _, p := range s[name] {
+			record := []string{
+				name,
+				p.Time.UTC().Format(time.RFC3339),
+				strconv.FormatFloat(p.Value, 'f', -1, 64),
+			}
+
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+var promInvalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// WritePrometheus writes the StatsSeries to w in the Prometheus text
+// exposition format. Every metric name is prefixed with prefix and every
+// sample is labeled with the given labels and its original timestamp.
+func (s StatsSeries) WritePrometheus(w io.Writer, prefix string, labels map[string]string) error {
+	labelStr := formatPrometheusLabels(labels)
+
+	for _, name := range s.sortedNames() {
+		metric := promInvalidNameChars.ReplaceAllString(prefix+name, "_")
+
+		if _, err := fmt.Fprintf(w, "# TYPE %s gauge\n", metric); err != nil {
+			return err
+		}
+
+		for _, p := range s[name] {
+			if _, err := fmt.Fprintf(
+				w,
+				"%s%s %s %d\n",
+				metric,
+				labelStr,
+				strconv.FormatFloat(p.Value, 'g', -1, 64),
+				p.Time.UnixMilli(),
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatPrometheusLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+
+	for i, k := range keys {
+		pairs[i] = fmt.Sprintf(
+			"%s=%s",
+			promInvalidNameChars.ReplaceAllString(k, "_"),
+			strconv.Quote(labels[k]),
+		)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
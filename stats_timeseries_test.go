@@ -0,0 +1,101 @@
+package linodego
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTimeSeries(t *testing.T) {
+	ts := NewTimeSeries([][]float64{
+		{1700000060000, 2},
+		{1700000000000, 1},
+		{1700000120000},
+	})
+
+	if len(ts) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(ts))
+	}
+
+	if !ts[0].Time.Equal(time.UnixMilli(1700000000000)) || ts[0].Value != 1 {
+		t.Fatalf("unexpected first point: %v", ts[0])
+	}
+
+	if !reflect.DeepEqual(ts.Raw(), [][]float64{{1700000000000, 1}, {1700000060000, 2}}) {
+		t.Fatalf("unexpected raw encoding: %v", ts.Raw())
+	}
+}
+
+func TestTimeSeries_Aggregations(t *testing.T) {
+	raw := make([][]float64, 0, 20)
+	for i := 1; i <= 20; i++ {
+		raw = append(raw, []float64{float64(i * 60000), float64(i)})
+	}
+
+	ts := NewTimeSeries(raw)
+
+	if ts.Avg() != 10.5 {
+		t.Errorf("expected avg 10.5, got %v", ts.Avg())
+	}
+
+	if ts.Max() != 20 || ts.Min() != 1 {
+		t.Errorf("unexpected max/min: %v/%v", ts.Max(), ts.Min())
+	}
+
+	if p95 := ts.P95(); math.Abs(p95-19.05) > 1e-9 {
+		t.Errorf("expected p95 19.05, got %v", p95)
+	}
+
+	rate := ts.Rate(2 * time.Minute)
+	if len(rate) != 19 {
+		t.Fatalf("expected 19 rate points, got %d", len(rate))
+	}
+
+	if math.Abs(rate[len(rate)-1].Value-1.0/60) > 1e-9 {
+		t.Errorf("unexpected rate: %v", rate[len(rate)-1].Value)
+	}
+
+	window := ts.Between(time.UnixMilli(5*60000), time.UnixMilli(7*60000))
+	if !reflect.DeepEqual(window.Values(), []float64{5, 6, 7}) {
+		t.Errorf("unexpected window: %v", window.Values())
+	}
+}
+
+func TestMergeTimeSeries(t *testing.T) {
+	a := NewTimeSeries([][]float64{{1000, 1}, {2000, 2}})
+	b := NewTimeSeries([][]float64{{2000, 20}, {3000, 3}})
+
+	merged := MergeTimeSeries(a, b)
+
+	if !reflect.DeepEqual(merged.Values(), []float64{1, 20, 3}) {
+		t.Fatalf("unexpected merged values: %v", merged.Values())
+	}
+}
+
+func TestStatsSeries_Export(t *testing.T) {
+	series := StatsSeries{
+		"cpu": NewTimeSeries([][]float64{{1700000000000, 1.5}}),
+	}
+
+	var csvBuf bytes.Buffer
+	if err := series.WriteCSV(&csvBuf); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedCSV := "metric,timestamp,value\ncpu,2023-11-14T22:13:20Z,1.5\n"
+	if csvBuf.String() != expectedCSV {
+		t.Errorf("unexpected csv output: %q", csvBuf.String())
+	}
+
+	var promBuf bytes.Buffer
+	if err := series.WritePrometheus(&promBuf, "linode_instance_", map[string]string{"linode_id": "123"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expectedProm := "# TYPE linode_instance_cpu gauge\nlinode_instance_cpu{linode_id=\"123\"} 1.5 1700000000000\n"
+	if promBuf.String() != expectedProm {
+		t.Errorf("unexpected prometheus output: %q", promBuf.String())
+	}
+}
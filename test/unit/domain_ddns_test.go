@@ -0,0 +1,214 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestUpdateDDNSRecords(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "domains/1/records"),
+		func(req *http.Request) (*http.Response, error) {
+			var filter map[string]string
+			if err := json.Unmarshal([]byte(req.Header.Get("X-Filter")), &filter); err != nil {
+				return nil, err
+			}
+
+			records := []linodego.DomainRecord{}
+			if filter["type"] == "A" && filter["name"] == "home" {
+				records = append(records, linodego.DomainRecord{ID: 10, Type: linodego.RecordTypeA, Name: "home", Target: "192.0.2.1"})
+			}
+
+			return httpmock.NewJsonResponse(200, map[string]any{"data": records, "page": 1, "pages": 1, "results": len(records)})
+		})
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "domains/1/records/10"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.DomainRecord{ID: 10}))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "domains/1/records"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.DomainRecord{ID: 11}))
+
+	updates, err := client.UpdateDDNSRecords(context.Background(), 1, "home", linodego.DDNSAddresses{
+		IPv4: "192.0.2.2",
+		IPv6: "2001:db8::2",
+	}, 300)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updates) != 2 {
+		t.Fatalf("unexpected updates: %v", updates)
+	}
+
+	if updates[0].Action != linodego.DDNSUpdated || updates[0].Previous != "192.0.2.1" || updates[0].RecordID != 10 {
+		t.Errorf("unexpected A update: %v", updates[0])
+	}
+
+	if updates[1].Action != linodego.DDNSCreated || updates[1].RecordID != 11 {
+		t.Errorf("unexpected AAAA update: %v", updates[1])
+	}
+
+	updates, err = client.UpdateDDNSRecords(context.Background(), 1, "home", linodego.DDNSAddresses{IPv4: "192.0.2.1"}, 0)
+	if err != nil || len(updates) != 1 || updates[0].Action != linodego.DDNSUnchanged {
+		t.Errorf("expected the A record to be unchanged, got %v, %v", updates, err)
+	}
+}
+
+func TestUpdateDDNSRecords_DuplicateRecords(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "domains/1/records"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.DomainRecord{
+				{ID: 10, Type: linodego.RecordTypeA, Name: "home", Target: "192.0.2.1"},
+				{ID: 12, Type: linodego.RecordTypeA, Name: "home", Target: "192.0.2.2"},
+				{ID: 13, Type: linodego.RecordTypeA, Name: "home", Target: "192.0.2.3"},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 3,
+		}))
+
+	deletes := 0
+
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "domains/1/records/"),
+		func(req *http.Request) (*http.Response, error) {
+			deletes++
+
+			return httpmock.NewJsonResponse(200, map[string]any{})
+		})
+
+	var updated []int
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "domains/1/records/1[0-9]$"),
+		func(req *http.Request) (*http.Response, error) {
+			var id int
+			if _, err := fmt.Sscanf(req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:], "%d", &id); err != nil {
+				return nil, err
+			}
+
+			updated = append(updated, id)
+
+			return httpmock.NewJsonResponse(200, linodego.DomainRecord{ID: id, Target: "192.0.2.2"})
+		})
+
+	// Every same-name record survives; the ones pointing elsewhere are updated
+	updates, err := client.UpdateDDNSRecords(context.Background(), 1, "home", linodego.DDNSAddresses{IPv4: "192.0.2.2"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(updates) != 3 || updates[1].Action != linodego.DDNSUnchanged || updates[1].RecordID != 12 {
+		t.Fatalf("unexpected updates: %v", updates)
+	}
+
+	if updates[0].Action != linodego.DDNSUpdated || updates[0].Previous != "192.0.2.1" {
+		t.Errorf("unexpected update: %v", updates[0])
+	}
+
+	if deletes != 0 {
+		t.Errorf("expected no records to be deleted, got %d deletions", deletes)
+	}
+
+	if len(updated) != 2 || updated[0] != 10 || updated[1] != 13 {
+		t.Errorf("expected records 10 and 13 to be updated, got %v", updated)
+	}
+}
+
+func TestRunDDNSUpdater(t *testing.T) {
+	client := createMockClient(t)
+
+	target := "192.0.2.1"
+	lookups := 0
+	writes := 0
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "domains/1/records"),
+		func(req *http.Request) (*http.Response, error) {
+			lookups++
+
+			return httpmock.NewJsonResponse(200, map[string]any{
+				"data":    []linodego.DomainRecord{{ID: 10, Type: linodego.RecordTypeA, Name: "home", Target: target}},
+				"page":    1,
+				"pages":   1,
+				"results": 1,
+			})
+		})
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "domains/1/records/10$"),
+		func(req *http.Request) (*http.Response, error) {
+			var opts linodego.DomainRecordUpdateOptions
+			if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			writes++
+			target = opts.Target
+
+			return httpmock.NewJsonResponse(200, linodego.DomainRecord{ID: 10, Target: target})
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	detections := 0
+	errorCount := 0
+
+	var changes [][]linodego.DDNSUpdate
+
+	err := client.RunDDNSUpdater(ctx, linodego.DDNSUpdaterOptions{
+		DomainID:   1,
+		Name:       "home",
+		Interval:   time.Millisecond,
+		MinBackoff: time.Millisecond,
+		Detector: func(ctx context.Context) (linodego.DDNSAddresses, error) {
+			detections++
+			switch detections {
+			case 1:
+				return linodego.DDNSAddresses{}, errors.New("detection failed")
+			case 3:
+				// The record is edited outside the updater while the address stays the same
+				target = "198.51.100.9"
+			case 5:
+				cancel()
+			}
+
+			return linodego.DDNSAddresses{IPv4: "192.0.2.1"}, nil
+		},
+		OnUpdate: func(updates []linodego.DDNSUpdate) {
+			changes = append(changes, updates)
+		},
+		OnError: func(error) {
+			errorCount++
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the context to be cancelled, got %v", err)
+	}
+
+	if errorCount != 1 {
+		t.Errorf("expected 1 error, got %d", errorCount)
+	}
+
+	// The records are re-read on every check, even though the detected address never changes
+	if lookups < 3 {
+		t.Errorf("expected the records to be looked up on every check, got %d lookups", lookups)
+	}
+
+	if writes != 1 || target != "192.0.2.1" {
+		t.Errorf("expected the external edit to be corrected once, got %d writes and target %s", writes, target)
+	}
+
+	if len(changes) != 1 || changes[0][0].Previous != "198.51.100.9" {
+		t.Errorf("expected OnUpdate to report only the correction, got %v", changes)
+	}
+}
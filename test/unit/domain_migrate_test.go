@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestImportAndCloneDomain(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "domains/import"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Domain{ID: 1, Domain: "example.com"}))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "domains/1/clone"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Domain{ID: 2, Domain: "example.net"}))
+
+	imported, err := client.ImportDomain(context.Background(), linodego.DomainImportOptions{
+		Domain: "example.com", RemoteNameserver: "ns1.example.org",
+	})
+	if err != nil || imported.ID != 1 {
+		t.Fatalf("unexpected import: %+v, %v", imported, err)
+	}
+
+	cloned, err := client.CloneDomain(context.Background(), 1, linodego.DomainCloneOptions{Domain: "example.net"})
+	if err != nil || cloned.Domain != "example.net" {
+		t.Fatalf("unexpected clone: %+v, %v", cloned, err)
+	}
+}
+
+func TestMigrateDomain(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "domains/1/records"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.DomainRecord{
+				{ID: 10, Type: linodego.RecordTypeA, Name: "www", Target: "192.0.2.10"},
+				{ID: 11, Type: linodego.RecordTypeMX, Target: "mail.example.com", Priority: 10},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "domains/1"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Domain{
+			ID: 1, Domain: "example.com", Type: linodego.DomainTypeMaster, SOAEmail: "old@example.com",
+		}))
+
+	var domainOpts linodego.DomainCreateOptions
+
+	var recordOpts []linodego.DomainRecordCreateOptions
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "domains/2/records"),
+		func(req *http.Request) (*http.Response, error) {
+			var opts linodego.DomainRecordCreateOptions
+			if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			recordOpts = append(recordOpts, opts)
+
+			return httpmock.NewJsonResponse(200, linodego.DomainRecord{ID: 20 + len(recordOpts), Type: opts.Type})
+		})
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "domains"),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&domainOpts); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewJsonResponse(200, linodego.Domain{ID: 2, Domain: domainOpts.Domain})
+		})
+
+	domain, records, err := linodego.MigrateDomain(context.Background(), client, client, 1, linodego.DomainMigrationOptions{
+		SOAEmails: map[string]string{"old@example.com": "new@example.com"},
+		Addresses: map[string]string{"192.0.2.10": "198.51.100.10"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if domain.ID != 2 || len(records) != 2 {
+		t.Fatalf("unexpected result: %+v %+v", domain, records)
+	}
+
+	if domainOpts.SOAEmail != "new@example.com" || domainOpts.Domain != "example.com" {
+		t.Errorf("unexpected domain options: %+v", domainOpts)
+	}
+
+	if recordOpts[0].Target != "198.51.100.10" || recordOpts[0].Priority != nil {
+		t.Errorf("unexpected A record options: %+v", recordOpts[0])
+	}
+
+	if recordOpts[1].Priority == nil || *recordOpts[1].Priority != 10 {
+		t.Errorf("unexpected MX record options: %+v", recordOpts[1])
+	}
+}
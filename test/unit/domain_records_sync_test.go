@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestSyncDomainRecords(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "domains/123/records"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.DomainRecord{
+				{ID: 1, Type: linodego.RecordTypeA, Name: "www", Target: "192.0.2.10"},
+				{ID: 2, Type: linodego.RecordTypeTXT, Name: "old", Target: "stale"},
+				{ID: 4, Type: linodego.RecordTypePTR, Name: "10", Target: "www.example.com"},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 3,
+		}))
+
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "domains/123/records/2"),
+		httpmock.NewStringResponder(200, "{}"))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "domains/123/records"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.DomainRecord{
+			ID: 3, Type: linodego.RecordTypeAAAA, Name: "www", Target: "2001:db8::1",
+		}))
+
+	desired := []linodego.DomainRecordCreateOptions{
+		{Type: linodego.RecordTypeA, Name: "www", Target: "192.0.2.10"},
+		{Type: linodego.RecordTypeAAAA, Name: "www", Target: "2001:db8::1"},
+		{Type: linodego.RecordTypeTXT, Name: "www", Target: "verified"},
+	}
+
+	changes, err := client.SyncDomainRecords(context.Background(), 123, desired, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The PTR record is not a managed type, so it is left alone
+	if len(changes) != 3 || httpmock.GetTotalCallCount() != 1 {
+		t.Fatalf("unexpected dry run: %v (%d calls)", changes, httpmock.GetTotalCallCount())
+	}
+
+	changes, err = client.SyncDomainRecords(context.Background(), 123, desired, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if changes[0].Action != linodego.DomainRecordSyncDelete || changes[0].RecordID != 2 {
+		t.Errorf("unexpected delete: %v", changes[0])
+	}
+
+	if changes[1].Action != linodego.DomainRecordSyncCreate || changes[1].RecordID != 3 {
+		t.Errorf("unexpected create: %v", changes[1])
+	}
+}
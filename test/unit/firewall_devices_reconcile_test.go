@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestReconcileFirewallDevices(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.Instance{
+				{ID: 1, Label: "web-1", Tags: []string{"web", "prod"}},
+				{ID: 2, Label: "web-2", Tags: []string{"web", "prod"}},
+				{ID: 3, Label: "web-3", Tags: []string{"web"}},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 3,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data":    []linodego.NodeBalancer{},
+			"page":    1,
+			"pages":   1,
+			"results": 0,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "networking/firewalls/123/devices"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.FirewallDevice{
+				{ID: 10, Entity: linodego.FirewallDeviceEntity{ID: 1, Type: linodego.FirewallDeviceLinode, Label: "web-1"}},
+				{ID: 11, Entity: linodego.FirewallDeviceEntity{ID: 4, Type: linodego.FirewallDeviceLinode, Label: "old"}},
+				{ID: 13, Entity: linodego.FirewallDeviceEntity{ID: 5, Type: "interface", Label: "eth1"}},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 3,
+		}))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "networking/firewalls/123/devices"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.FirewallDevice{
+			ID: 12, Entity: linodego.FirewallDeviceEntity{ID: 2, Type: linodego.FirewallDeviceLinode},
+		}))
+
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "networking/firewalls/123/devices/11"),
+		httpmock.NewStringResponder(200, "{}"))
+
+	result, err := client.ReconcileFirewallDevices(context.Background(), 123, linodego.FirewallDeviceSelector{
+		Tags:         []string{"web", "prod"},
+		LabelPattern: "web-*",
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Device types the selector does not cover are left attached
+	if result.Unchanged != 1 || len(result.Changes) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	attach, detach := result.Changes[0], result.Changes[1]
+
+	if attach.Action != linodego.FirewallDeviceAttach || attach.EntityID != 2 || attach.DeviceID != 12 {
+		t.Errorf("unexpected attach: %+v", attach)
+	}
+
+	if detach.Action != linodego.FirewallDeviceDetach || detach.EntityID != 4 || detach.DeviceID != 11 {
+		t.Errorf("unexpected detach: %+v", detach)
+	}
+}
@@ -0,0 +1,66 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestApplyInstanceConfigInterfaces_DemotesPrimary(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1/configs/2/interfaces$"),
+		httpmock.NewJsonResponderOrPanic(200, []linodego.InstanceConfigInterface{
+			{ID: 10, Purpose: linodego.InterfacePurposePublic, Primary: true},
+			{ID: 11, Purpose: linodego.InterfacePurposeVPC, SubnetID: linodego.Pointer(5)},
+		}))
+
+	bodies := make(map[string]map[string]any)
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "linode/instances/1/configs/2/interfaces/1[01]$"),
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+
+			bodies[req.URL.Path] = body
+
+			return httpmock.NewJsonResponse(200, linodego.InstanceConfigInterface{})
+		})
+
+	desired, err := linodego.NewInstanceConfigInterfacesBuilder().
+		Public().
+		VPC(5, nil).Primary().
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.ApplyInstanceConfigInterfaces(context.Background(), 1, 2, desired, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected two interface updates, got %v", bodies)
+	}
+
+	for path, body := range bodies {
+		primary, ok := body["primary"]
+
+		switch path[len(path)-2:] {
+		case "10":
+			if !ok || primary != false {
+				t.Errorf("expected the old primary to be demoted explicitly, got %v", body)
+			}
+		case "11":
+			if primary != true {
+				t.Errorf("expected the VPC interface to be promoted, got %v", body)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestInstancePlan_ResizeDown(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/123/disks"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data":    []linodego.InstanceDisk{{ID: 1, Size: 40000}, {ID: 2, Size: 512}},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/123"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{
+			ID: 123, Region: "us-east", Type: "g6-standard-2", Status: linodego.InstanceRunning,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/types/g6-standard-2"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.LinodeType{
+			ID: "g6-standard-2", Class: linodego.ClassStandard, Disk: 81920,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/types/g6-nanode-1"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.LinodeType{
+			ID: "g6-nanode-1", Class: linodego.ClassNanode, Disk: 25600,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "regions/availability"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.RegionAvailability{
+				{Region: "us-east", Plan: "g6-nanode-1", Available: false},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 1,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "regions/us-east"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Region{
+			ID: "us-east", Status: "ok", Capabilities: []string{linodego.CapabilityLinodes},
+		}))
+
+	plan, err := client.PlanInstanceChange(context.Background(), 123, linodego.InstancePlanOptions{
+		Type: "g6-nanode-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.Feasible() {
+		t.Fatal("expected plan to be infeasible")
+	}
+
+	checks := make(map[linodego.InstancePlanCheck]bool)
+	for _, issue := range plan.Errors() {
+		checks[issue.Check] = true
+	}
+
+	if !checks[linodego.InstancePlanCheckAvailability] || !checks[linodego.InstancePlanCheckDisk] {
+		t.Fatalf("expected availability and disk errors, got %v", plan.Issues)
+	}
+
+	if opts := plan.ResizeOptions(); opts == nil || opts.Type != "g6-nanode-1" {
+		t.Fatalf("unexpected resize options: %v", opts)
+	}
+
+	if plan.MigrateOptions() != nil {
+		t.Fatal("expected no migrate options")
+	}
+}
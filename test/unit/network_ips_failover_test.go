@@ -0,0 +1,219 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+type fakeIPResolver map[string][]string
+
+func (r fakeIPResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	addrs := make([]net.IPAddr, 0, len(r[host]))
+	for _, address := range r[host] {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(address)})
+	}
+
+	return addrs, nil
+}
+
+func TestShareIPAddressesForFailover_regionMismatch(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 1, Region: "us-east"}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 2, Region: "eu-west"}))
+
+	shares := 0
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "networking/ips/share"),
+		func(*http.Request) (*http.Response, error) {
+			shares++
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	_, err := client.ShareIPAddressesForFailover(context.Background(), 1, 2, "192.0.2.1")
+	if err == nil || !strings.Contains(err.Error(), "region") {
+		t.Fatalf("expected a region mismatch error, got %v", err)
+	}
+
+	if shares != 0 {
+		t.Errorf("expected no share requests, got %d", shares)
+	}
+}
+
+func TestShareIPAddressesForFailover(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 1, Region: "us-east"}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 2, Region: "us-east"}))
+
+	shared := []*linodego.InstanceIP{{Address: "192.0.2.9"}}
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2/ips"),
+		func(*http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(200, linodego.InstanceIPAddressResponse{
+				IPv4: &linodego.InstanceIPv4Response{Shared: shared},
+			})
+		})
+
+	var requested linodego.IPAddressesShareOptions
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "networking/ips/share"),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&requested); err != nil {
+				return nil, err
+			}
+
+			shared = append(shared, &linodego.InstanceIP{Address: "192.0.2.1"})
+
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	result, err := client.ShareIPAddressesForFailover(context.Background(), 1, 2, "192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requested.IPs) != 2 || requested.IPs[0] != "192.0.2.9" || requested.IPs[1] != "192.0.2.1" {
+		t.Errorf("expected the existing share to be preserved, got %v", requested.IPs)
+	}
+
+	if len(result.IPv4.Shared) != 2 {
+		t.Errorf("unexpected result: %+v", result.IPv4)
+	}
+}
+
+func TestAssignIPAddress(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "networking/ips/192.0.2.1"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIP{Address: "192.0.2.1", Region: "us-east", LinodeID: 1}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 2, Region: "us-east"}))
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "networking/ips/assign"),
+		httpmock.NewStringResponder(200, "{}"))
+
+	// The address never shows up on the target
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2/ips"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIPAddressResponse{
+			IPv4: &linodego.InstanceIPv4Response{Public: []*linodego.InstanceIP{{Address: "192.0.2.2"}}},
+		}))
+
+	if _, err := client.AssignIPAddress(context.Background(), "192.0.2.1", 2); err == nil ||
+		!strings.Contains(err.Error(), "is not assigned") {
+		t.Fatalf("expected a verification error, got %v", err)
+	}
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2/ips"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIPAddressResponse{
+			IPv4: &linodego.InstanceIPv4Response{Public: []*linodego.InstanceIP{{Address: "192.0.2.1"}}},
+		}))
+
+	if _, err := client.AssignIPAddress(context.Background(), "192.0.2.1", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 2, Region: "eu-west"}))
+
+	if _, err := client.AssignIPAddress(context.Background(), "192.0.2.1", 2); err == nil ||
+		!strings.Contains(err.Error(), "region") {
+		t.Fatalf("expected a region mismatch error, got %v", err)
+	}
+}
+
+func TestSwapIPAddresses(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 1, Region: "us-east"}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 2, Region: "us-east"}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "networking/ips/192.0.2.1"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIP{Address: "192.0.2.1", LinodeID: 1}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "networking/ips/192.0.2.2"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIP{Address: "192.0.2.2", LinodeID: 2}))
+
+	var assigned linodego.LinodesAssignIPsOptions
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "networking/ips/assign"),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&assigned); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1/ips"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIPAddressResponse{
+			IPv4: &linodego.InstanceIPv4Response{Public: []*linodego.InstanceIP{{Address: "192.0.2.2"}}},
+		}))
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/2/ips"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.InstanceIPAddressResponse{
+			IPv4: &linodego.InstanceIPv4Response{Public: []*linodego.InstanceIP{{Address: "192.0.2.1"}}},
+		}))
+
+	if err := client.SwapIPAddresses(context.Background(), 1, "192.0.2.1", 2, "192.0.2.2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if assigned.Region != "us-east" || len(assigned.Assignments) != 2 ||
+		assigned.Assignments[0].LinodeID != 2 || assigned.Assignments[1].LinodeID != 1 {
+		t.Errorf("unexpected assignments: %+v", assigned)
+	}
+
+	if err := client.SwapIPAddresses(context.Background(), 2, "192.0.2.1", 1, "192.0.2.2"); err == nil {
+		t.Error("expected an error when an address is not assigned to the given linode")
+	}
+}
+
+func TestUpdateIPAddressesRDNS(t *testing.T) {
+	client := createMockClient(t)
+
+	updated := make(map[string]string)
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "networking/ips/"),
+		func(req *http.Request) (*http.Response, error) {
+			var opts linodego.IPAddressUpdateOptions
+			if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			address := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			updated[address] = *opts.RDNS
+
+			return httpmock.NewJsonResponse(200, linodego.InstanceIP{Address: address, RDNS: *opts.RDNS})
+		})
+
+	resolver := fakeIPResolver{
+		"good.example.com": {"192.0.2.1"},
+		"bad.example.com":  {"198.51.100.1"},
+	}
+
+	results, err := client.UpdateIPAddressesRDNS(context.Background(), []linodego.IPAddressRDNSUpdate{
+		{Address: "192.0.2.1", RDNS: "good.example.com"},
+		{Address: "192.0.2.2", RDNS: "bad.example.com"},
+	}, resolver)
+	if err == nil || !strings.Contains(err.Error(), "does not resolve") {
+		t.Fatalf("expected a forward resolution error, got %v", err)
+	}
+
+	if results[0].Err != nil || results[0].IP == nil || results[1].Err == nil {
+		t.Errorf("unexpected results: %+v", results)
+	}
+
+	if len(updated) != 1 || updated["192.0.2.1"] != "good.example.com" {
+		t.Errorf("expected only the resolving hostname to be applied, got %v", updated)
+	}
+}
@@ -0,0 +1,77 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestGetNodeBalancerHealth(t *testing.T) {
+	client := createMockClient(t)
+
+	total := 12.5
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.NodeBalancer{
+			ID: 123, Transfer: linodego.NodeBalancerTransfer{Total: &total},
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs$"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerConfig{
+				{ID: 1, Port: 80, Check: linodego.CheckHTTP, CheckPath: "/healthz"},
+				{ID: 2, Port: 443, Check: linodego.CheckConnection},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs/1/nodes"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerNode{
+				{ID: 10, Status: "UP", Mode: linodego.ModeAccept},
+				{ID: 11, Status: "DOWN", Mode: linodego.ModeAccept},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs/2/nodes"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerNode{
+				{ID: 20, Status: "DOWN", Mode: linodego.ModeAccept},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 1,
+		}))
+
+	// Stats are not available yet for new NodeBalancers
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/stats"),
+		httpmock.NewJsonResponderOrPanic(404, map[string]any{
+			"errors": []map[string]string{{"reason": "Stats are unavailable at this time."}},
+		}))
+
+	report, err := client.GetNodeBalancerHealth(context.Background(), 123)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Healthy || report.StatsAvailable || *report.Transfer.Total != total || len(report.Configs) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	web, tcp := report.Configs[0], report.Configs[1]
+
+	if web.Up != 1 || web.Down != 1 || !web.SomeBackendsDown || web.AllBackendsDown || len(web.Issues) != 1 {
+		t.Errorf("unexpected HTTP config health: %+v", web)
+	}
+
+	if !tcp.SomeBackendsDown || !tcp.AllBackendsDown || tcp.Modes[linodego.ModeAccept] != 1 {
+		t.Errorf("unexpected TCP config health: %+v", tcp)
+	}
+}
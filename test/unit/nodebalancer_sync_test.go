@@ -0,0 +1,146 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestNodeBalancerSync_DryRun(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs/456/nodes"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerNode{
+				{ID: 1, Address: "192.168.0.1:80", Label: "old", Mode: linodego.ModeAccept},
+				{ID: 2, Address: "192.168.0.2:80", Label: "kept", Mode: linodego.ModeAccept},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerConfig{
+				{ID: 456, Port: 80, Protocol: linodego.ProtocolHTTP},
+				{ID: 789, Port: 8080, Protocol: linodego.ProtocolHTTP},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	result, err := client.SyncNodeBalancer(context.Background(), 123, linodego.NodeBalancerSyncOptions{
+		Configs: []linodego.NodeBalancerConfigCreateOptions{
+			{
+				Port:     80,
+				Protocol: linodego.ProtocolHTTP,
+				Nodes: []linodego.NodeBalancerNodeCreateOptions{
+					{Address: "192.168.0.2:80", Label: "kept"},
+					{Address: "192.168.0.3:80", Label: "new"},
+				},
+			},
+			{Port: 443, Protocol: linodego.ProtocolTCP},
+		},
+		PruneConfigs: true,
+		DryRun:       true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := make([]linodego.NodeBalancerSyncAction, len(result.Changes))
+	for i, change := range result.Changes {
+		actions[i] = change.Action
+	}
+
+	expected := []linodego.NodeBalancerSyncAction{
+		linodego.NodeBalancerSyncCreateNode,
+		linodego.NodeBalancerSyncDrainNode,
+		linodego.NodeBalancerSyncDeleteNode,
+		linodego.NodeBalancerSyncCreateConfig,
+		linodego.NodeBalancerSyncDeleteConfig,
+	}
+
+	if !reflect.DeepEqual(actions, expected) {
+		t.Fatalf("unexpected changes: %v", result.Changes)
+	}
+
+	if result.Changes[1].NodeID != 1 || result.Changes[4].ConfigID != 789 {
+		t.Fatalf("unexpected change targets: %v", result.Changes)
+	}
+}
+
+func TestNodeBalancerSync_RotatesNodesOneAtATime(t *testing.T) {
+	client := createMockClient(t)
+	client.SetPollDelay(10 * time.Millisecond)
+
+	cert := generateTestCertificate(t, time.Now().Add(time.Hour))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs/456/nodes"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerNode{
+				{ID: 1, Address: "192.168.0.1:443", Mode: linodego.ModeAccept, Status: "UP"},
+				{ID: 2, Address: "192.168.0.2:443", Mode: linodego.ModeAccept, Status: "UP"},
+				{ID: 3, Address: "192.168.0.3:443", Mode: linodego.ModeAccept, Status: "UP"},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 3,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs/456$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.NodeBalancerConfig{
+			ID: 456, Port: 443, NodesStatus: &linodego.NodeBalancerNodeStatus{Up: 3},
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs$"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerConfig{
+				{
+					ID: 456, Port: 443, Protocol: linodego.ProtocolHTTPS,
+					SSLCert: "<REDACTED>", SSLKey: "<REDACTED>", SSLFingerprint: cert.Fingerprint(),
+				},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 1,
+		}))
+
+	calls := make([]string, 0)
+	record := func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, req.Method+" "+req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:])
+		return httpmock.NewStringResponse(200, "{}"), nil
+	}
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "nodebalancers/123/configs/456"), record)
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "nodebalancers/123/configs/456/nodes/"), record)
+
+	result, err := client.SyncNodeBalancer(context.Background(), 123, linodego.NodeBalancerSyncOptions{
+		Configs: []linodego.NodeBalancerConfigCreateOptions{
+			{
+				Port:     443,
+				Protocol: linodego.ProtocolHTTPS,
+				SSLCert:  cert.CertPEM,
+				SSLKey:   "key",
+				Nodes:    []linodego.NodeBalancerNodeCreateOptions{{Address: "192.168.0.3:443"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The redacted certificate matches the fingerprint, so the config is not rewritten
+	expected := []string{"PUT 1", "DELETE 1", "PUT 2", "DELETE 2"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("unexpected calls: %v (changes %v)", calls, result.Changes)
+	}
+}
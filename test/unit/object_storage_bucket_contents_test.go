@@ -0,0 +1,60 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestObjectStorageBucketContentsIterator(t *testing.T) {
+	client := createMockClient(t)
+
+	var prefixes []string
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets/us-east/my-bucket/object-list"),
+		func(req *http.Request) (*http.Response, error) {
+			prefixes = append(prefixes, req.URL.Query().Get("prefix"))
+
+			if req.URL.Query().Get("marker") == "" {
+				return httpmock.NewJsonResponse(200, map[string]any{
+					"data": []map[string]any{
+						{"name": "logs/a.txt", "size": 10, "etag": "abc", "last_modified": "2024-01-02T03:04:05.678Z", "owner": "me"},
+						{"name": "logs/b.txt", "size": 20},
+					},
+					"is_truncated": true,
+					"next_marker":  "logs/b.txt",
+				})
+			}
+
+			if marker := req.URL.Query().Get("marker"); marker != "logs/b.txt" {
+				t.Errorf("unexpected marker %q", marker)
+			}
+
+			return httpmock.NewJsonResponse(200, map[string]any{
+				"data":         []map[string]any{{"name": "logs/c.txt", "size": 30}},
+				"is_truncated": false,
+				"next_marker":  nil,
+			})
+		})
+
+	objects, err := client.ListAllObjectStorageBucketContents(context.Background(), "us-east", "my-bucket",
+		&linodego.ObjectStorageBucketListContentsParams{Prefix: linodego.Pointer("logs/")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(objects) != 3 || objects[2].Name != "logs/c.txt" {
+		t.Fatalf("unexpected objects: %+v", objects)
+	}
+
+	if objects[0].LastModified == nil || objects[0].LastModified.Year() != 2024 || objects[0].Etag != "abc" {
+		t.Errorf("unexpected object: %+v", objects[0])
+	}
+
+	if len(prefixes) != 2 || prefixes[1] != "logs/" {
+		t.Errorf("expected the prefix to be kept across pages, got %v", prefixes)
+	}
+}
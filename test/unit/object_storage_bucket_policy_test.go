@@ -0,0 +1,99 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestAuditObjectStoragePublicObjects(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets/us-east/site/access"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageBucketAccess{ACL: linodego.ACLPrivate}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets/us-east/site/object-list"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data":         []map[string]any{{"name": "index.html"}, {"name": "secret.txt"}},
+			"is_truncated": false,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets/us-east/site/object-acl"),
+		func(req *http.Request) (*http.Response, error) {
+			acl := "private"
+			if req.URL.Query().Get("name") == "index.html" {
+				acl = "public-read"
+			}
+
+			return httpmock.NewJsonResponse(200, linodego.ObjectStorageObjectACLConfig{ACL: acl})
+		})
+
+	audit, err := client.AuditObjectStoragePublicObjects(context.Background(), "us-east", "site", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if audit.BucketPublic || audit.Checked != 2 || len(audit.PublicObjects) != 1 || audit.PublicObjects[0].Name != "index.html" {
+		t.Errorf("unexpected audit: %+v", audit)
+	}
+}
+
+func TestEnableObjectStorageStaticSite(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets/us-east/site/access"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageBucketAccess{ACL: linodego.ACLPrivate}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets/us-east/site/ssl"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageBucketCert{SSL: false}))
+
+	var calls []string
+
+	record := func(status int, body any) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, req.Method+" "+req.URL.Path[strings.Index(req.URL.Path, "object-storage"):])
+			return httpmock.NewJsonResponse(status, body)
+		}
+	}
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/buckets/us-east/site/access"), record(200, map[string]any{}))
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/buckets/us-east/site/ssl"), record(200, linodego.ObjectStorageBucketCert{SSL: true}))
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "object-storage/buckets/us-east/site/ssl"), record(200, map[string]any{}))
+
+	plan, err := client.EnableObjectStorageStaticSite(context.Background(), "us-east", "site", linodego.ObjectStorageStaticSiteOptions{
+		Cert: &linodego.ObjectStorageBucketCertUploadOptions{Certificate: "cert", PrivateKey: "key"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reverse, err := plan.Reverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.ApplyObjectStorageStaticSitePlan(context.Background(), *reverse); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"POST object-storage/buckets/us-east/site/access",
+		"POST object-storage/buckets/us-east/site/ssl",
+		"POST object-storage/buckets/us-east/site/access",
+		"DELETE object-storage/buckets/us-east/site/ssl",
+	}
+
+	if len(calls) != len(expected) {
+		t.Fatalf("unexpected calls: %v", calls)
+	}
+
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("call %d: expected %s, got %s", i, expected[i], calls[i])
+		}
+	}
+}
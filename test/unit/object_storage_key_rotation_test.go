@@ -0,0 +1,71 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestRotateObjectStorageKey(t *testing.T) {
+	client := createMockClient(t)
+
+	access := []linodego.ObjectStorageKeyBucketAccess{{Region: "us-east", BucketName: "data", Permissions: "read_write"}}
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/keys/1"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageKey{ID: 1, Label: "app", Limited: true, BucketAccess: &access}))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/keys"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageKey{ID: 2, AccessKey: "NEW", SecretKey: "new-secret"}))
+
+	var deleted []string
+
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "object-storage/keys/[0-9]+"),
+		func(req *http.Request) (*http.Response, error) {
+			deleted = append(deleted, req.URL.Path)
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	var stored string
+
+	result, err := client.RotateObjectStorageKey(context.Background(), 1, linodego.ObjectStorageKeyRotationOptions{
+		Sink: func(_ context.Context, key linodego.ObjectStorageKey) error {
+			stored = key.SecretKey
+			return nil
+		},
+		Confirm: func(context.Context, linodego.ObjectStorageKey) (bool, error) {
+			return true, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stored != "new-secret" || !result.OldKeyDeleted || result.NewKeyID != 2 {
+		t.Errorf("unexpected rotation: %+v, stored %q", result, stored)
+	}
+
+	if len(deleted) != 1 || !strings.HasSuffix(deleted[0], "/object-storage/keys/1") {
+		t.Errorf("expected the old key to be deleted, got %v", deleted)
+	}
+
+	// A failing sink rolls back the replacement key
+	deleted = nil
+
+	_, err = client.RotateObjectStorageKey(context.Background(), 1, linodego.ObjectStorageKeyRotationOptions{
+		Sink: func(context.Context, linodego.ObjectStorageKey) error {
+			return errors.New("write failed")
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(deleted) != 1 || !strings.HasSuffix(deleted[0], "/object-storage/keys/2") {
+		t.Errorf("expected the replacement key to be deleted, got %v", deleted)
+	}
+}
@@ -0,0 +1,132 @@
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestBootstrapObjectStorageS3(t *testing.T) {
+	client := createMockClient(t)
+
+	access := []linodego.ObjectStorageKeyBucketAccess{
+		{Region: "us-east", BucketName: "backups", Permissions: linodego.ObjectStorageKeyReadWrite},
+	}
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/clusters"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data":    []linodego.ObjectStorageCluster{{ID: "us-east-1", Region: "us-east", Domain: "us-east-1.linodeobjects.com"}},
+			"page":    1,
+			"pages":   1,
+			"results": 1,
+		}))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/keys"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageKey{
+			ID:           7,
+			AccessKey:    "AKIA",
+			SecretKey:    "secret",
+			Limited:      true,
+			BucketAccess: &access,
+			Regions:      []linodego.ObjectStorageKeyRegion{{ID: "us-east", S3Endpoint: "us-east-1.linodeobjects.com"}},
+		}))
+
+	deleted := 0
+
+	httpmock.RegisterRegexpResponder("DELETE", mockRequestURL(t, "object-storage/keys/7"),
+		func(*http.Request) (*http.Response, error) {
+			deleted++
+			return httpmock.NewStringResponse(200, "{}"), nil
+		})
+
+	cfg, cleanup, err := client.BootstrapObjectStorageS3(context.Background(), linodego.ObjectStorageS3BootstrapOptions{
+		Region:  "us-east",
+		Buckets: []string{"backups"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Endpoint != "https://us-east-1.linodeobjects.com" || cfg.Region != "us-east-1" || cfg.SecretAccessKey != "secret" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	creds, err := cfg.Retrieve(context.Background())
+	if err != nil || creds.AccessKeyID != "AKIA" {
+		t.Errorf("unexpected credentials: %+v, %v", creds, err)
+	}
+
+	// The key still grants access, so it is reused
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/keys/7"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageKey{
+			ID: 7, AccessKey: "AKIA", Limited: true, BucketAccess: &access,
+		}))
+
+	reused, reuseCleanup, err := client.BootstrapObjectStorageS3(context.Background(), linodego.ObjectStorageS3BootstrapOptions{
+		Region:      "us-east",
+		Buckets:     []string{"backups"},
+		Permissions: linodego.ObjectStorageKeyReadOnly,
+		Existing:    cfg,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *reused != *cfg {
+		t.Errorf("expected the credentials to be reused, got %+v", reused)
+	}
+
+	if err := reuseCleanup(context.Background()); err != nil || deleted != 0 {
+		t.Errorf("reused credentials must not be deleted: %v, %d", err, deleted)
+	}
+
+	if err := cleanup(context.Background()); err != nil || deleted != 1 {
+		t.Errorf("expected the key to be deleted: %v, %d", err, deleted)
+	}
+}
+
+func TestBootstrapObjectStorageS3_ClusterID(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/clusters"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data":    []linodego.ObjectStorageCluster{{ID: "us-east-1", Region: "us-east", Domain: "us-east-1.linodeobjects.com"}},
+			"page":    1,
+			"pages":   1,
+			"results": 1,
+		}))
+
+	var requested linodego.ObjectStorageKeyCreateOptions
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/keys"),
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&requested); err != nil {
+				return nil, err
+			}
+
+			return httpmock.NewJsonResponse(200, linodego.ObjectStorageKey{
+				ID: 8, AccessKey: "AKIA", SecretKey: "secret", Limited: true, BucketAccess: requested.BucketAccess,
+			})
+		})
+
+	cfg, _, err := client.BootstrapObjectStorageS3(context.Background(), linodego.ObjectStorageS3BootstrapOptions{
+		Region:  "us-east-1",
+		Buckets: []string{"backups"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The deprecated cluster ID is sent as the cluster of the bucket access
+	if access := (*requested.BucketAccess)[0]; access.Cluster != "us-east-1" || access.Region != "" {
+		t.Errorf("unexpected bucket access: %+v", access)
+	}
+
+	if cfg.Endpoint != "https://us-east-1.linodeobjects.com" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
@@ -0,0 +1,179 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+const transferObjectURL = "https://my-bucket.us-east-1.linodeobjects.com/report.csv"
+
+func newTestTransferManager(t *testing.T) *linodego.ObjectStorageTransferManager {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/buckets/us-east/my-bucket/object-url"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageObjectURL{URL: transferObjectURL + "?X-Amz-Signature=abc"}))
+
+	manager := client.NewObjectStorageTransferManager("us-east", "my-bucket")
+	manager.HTTPClient = &http.Client{Transport: httpmock.DefaultTransport}
+	manager.RetryDelay = time.Millisecond
+
+	return manager
+}
+
+func TestObjectStorageTransferManager_Upload(t *testing.T) {
+	manager := newTestTransferManager(t)
+
+	content := "a,b,c\n1,2,3\n"
+	sum := md5.Sum([]byte(content))
+	attempts := 0
+
+	httpmock.RegisterResponder("PUT", transferObjectURL, func(req *http.Request) (*http.Response, error) {
+		attempts++
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempts == 1 {
+			return httpmock.NewStringResponse(503, "slow down"), nil
+		}
+
+		if attempts == 2 && (string(body) != content || req.ContentLength != int64(len(content)) ||
+			req.Header.Get("Content-Type") != "text/csv") {
+			t.Errorf("unexpected upload: %q, %d, %v", body, req.ContentLength, req.Header)
+		}
+
+		resp := httpmock.NewStringResponse(200, "")
+		resp.Header.Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+
+		return resp, nil
+	})
+
+	var progress int64
+
+	result, err := manager.Upload(context.Background(), "report.csv", strings.NewReader(content), &linodego.ObjectStorageTransferOptions{
+		ContentType: "text/csv",
+		Progress: func(transferred, total int64) {
+			progress = transferred
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Attempts != 2 || result.Size != int64(len(content)) || progress != result.Size {
+		t.Errorf("unexpected result: %+v, progress %d", result, progress)
+	}
+
+	if _, err := manager.Upload(context.Background(), "report.csv", bytes.NewReader([]byte(content)), &linodego.ObjectStorageTransferOptions{
+		MD5: "00000000000000000000000000000000",
+	}); !errors.Is(err, linodego.ErrObjectStorageChecksumMismatch) {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+}
+
+// failingReader returns its data followed by an error, like a dropped connection
+type failingReader struct {
+	data []byte
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, errors.New("connection reset")
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+
+	return n, nil
+}
+
+func TestObjectStorageTransferManager_DownloadResume(t *testing.T) {
+	manager := newTestTransferManager(t)
+
+	content := "0123456789abcdef"
+	sum := md5.Sum([]byte(content))
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	httpmock.RegisterResponder("GET", transferObjectURL, func(req *http.Request) (*http.Response, error) {
+		if req.Header.Get("Range") == "" {
+			return &http.Response{
+				StatusCode:    200,
+				Header:        http.Header{"Etag": []string{etag}},
+				Body:          io.NopCloser(&failingReader{data: []byte(content[:6])}),
+				ContentLength: int64(len(content)),
+			}, nil
+		}
+
+		if req.Header.Get("Range") != "bytes=6-" {
+			t.Errorf("unexpected range %q", req.Header.Get("Range"))
+		}
+
+		resp := httpmock.NewStringResponse(206, content[6:])
+		resp.Header.Set("ETag", etag)
+		resp.Header.Set("Content-Range", "bytes 6-15/16")
+
+		return resp, nil
+	})
+
+	var buf bytes.Buffer
+
+	result, err := manager.Download(context.Background(), "report.csv", &buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != content || result.Attempts != 2 || result.MD5 != hex.EncodeToString(sum[:]) {
+		t.Errorf("unexpected download %q: %+v", buf.String(), result)
+	}
+}
+
+func TestObjectStorageTransferManager_ContentDisposition(t *testing.T) {
+	manager := newTestTransferManager(t)
+
+	presigned := make(map[string]linodego.ObjectStorageObjectURLCreateOptions)
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/buckets/us-east/my-bucket/object-url"),
+		func(req *http.Request) (*http.Response, error) {
+			var opts linodego.ObjectStorageObjectURLCreateOptions
+			if err := json.NewDecoder(req.Body).Decode(&opts); err != nil {
+				return nil, err
+			}
+
+			presigned[opts.Method] = opts
+
+			return httpmock.NewJsonResponse(200, linodego.ObjectStorageObjectURL{URL: transferObjectURL + "?X-Amz-Signature=abc"})
+		})
+
+	httpmock.RegisterResponder("PUT", transferObjectURL, httpmock.NewStringResponder(200, ""))
+	httpmock.RegisterResponder("GET", transferObjectURL, httpmock.NewStringResponder(200, "a,b,c\n"))
+
+	opts := &linodego.ObjectStorageTransferOptions{ContentDisposition: "attachment; filename=report.csv"}
+
+	if _, err := manager.Upload(context.Background(), "report.csv", strings.NewReader("a,b,c\n"), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := manager.Download(context.Background(), "report.csv", &buf, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// The disposition is only requested when presigning downloads
+	if presigned[http.MethodPut].ContentDisposition != "" || presigned[http.MethodGet].ContentDisposition != opts.ContentDisposition {
+		t.Errorf("unexpected presign requests: %+v", presigned)
+	}
+}
@@ -0,0 +1,42 @@
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestGetObjectStorageUsageReport(t *testing.T) {
+	client := createMockClient(t)
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/buckets"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.ObjectStorageBucket{
+				{Label: "logs", Cluster: "us-east-1", Region: "us-east", Size: 300, Objects: 3},
+				{Label: "assets", Cluster: "eu-central-1", Region: "eu-central", Size: 100, Objects: 2},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 2,
+		}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "object-storage/transfer"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageTransfer{AmmountUsed: 80}))
+
+	report, err := client.GetObjectStorageUsageReport(context.Background(), linodego.ObjectStorageUsageOptions{
+		GroupBy: linodego.ObjectStorageUsageByCluster,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.TransferUsed != 80 || report.TotalObjects != 5 || len(report.Groups) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	if group := report.Groups[1]; group.Key != "us-east-1" || group.Size != 300 {
+		t.Errorf("unexpected group: %+v", group)
+	}
+}
@@ -0,0 +1,117 @@
+package unit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func generateTestCertificate(t *testing.T, notAfter time.Time) *linodego.TLSCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := linodego.LoadTLSCertificate(
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func TestUpdateNodeBalancerCertificates(t *testing.T) {
+	client := createMockClient(t)
+	cert := generateTestCertificate(t, time.Now().Add(10*24*time.Hour))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "nodebalancers/123/configs"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.NodeBalancerConfig{
+				{ID: 1, Port: 443, Protocol: linodego.ProtocolHTTPS, SSLFingerprint: cert.Fingerprint()},
+				{ID: 2, Port: 8443, Protocol: linodego.ProtocolHTTPS, SSLFingerprint: "AA:BB"},
+				{ID: 3, Port: 80, Protocol: linodego.ProtocolHTTP},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 3,
+		}))
+
+	httpmock.RegisterRegexpResponder("PUT", mockRequestURL(t, "nodebalancers/123/configs/2"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.NodeBalancerConfig{ID: 2}))
+
+	rollout, err := client.UpdateNodeBalancerCertificates(context.Background(), 123, cert,
+		linodego.TLSCertificateDeployOptions{RenewBefore: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rollout.Warning == "" {
+		t.Error("expected a warning for a certificate expiring within the renewal window")
+	}
+
+	if len(rollout.Configs) != 2 || rollout.Configs[0].Updated || !rollout.Configs[1].Updated {
+		t.Errorf("unexpected configs: %+v", rollout.Configs)
+	}
+}
+
+func TestUploadObjectStorageBucketTLSCertificate(t *testing.T) {
+	client := createMockClient(t)
+	cert := generateTestCertificate(t, time.Now().Add(90*24*time.Hour))
+
+	httpmock.RegisterRegexpResponder("POST", mockRequestURL(t, "object-storage/buckets/us-east/site/ssl"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.ObjectStorageBucketCert{SSL: true}))
+
+	result, err := client.UploadObjectStorageBucketTLSCertificate(context.Background(), "us-east", "site", cert,
+		linodego.TLSCertificateDeployOptions{RenewBefore: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Warning != "" || !result.Cert.SSL {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	expiring := generateTestCertificate(t, time.Now().Add(24*time.Hour))
+
+	result, err = client.UploadObjectStorageBucketTLSCertificate(context.Background(), "us-east", "site", expiring,
+		linodego.TLSCertificateDeployOptions{RenewBefore: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Warning == "" {
+		t.Error("expected a warning for a certificate expiring within the renewal window")
+	}
+}
@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/linode/linodego"
+)
+
+func TestGetVLANInventoryEntry(t *testing.T) {
+	client := createMockClient(t)
+
+	var filter string
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "networking/vlans"),
+		func(req *http.Request) (*http.Response, error) {
+			filter = req.Header.Get("X-Filter")
+
+			return httpmock.NewJsonResponse(200, map[string]any{
+				"data":    []linodego.VLAN{{Label: "backend", Region: "us-east", Linodes: []int{1}}},
+				"page":    1,
+				"pages":   1,
+				"results": 1,
+			})
+		})
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1$"),
+		httpmock.NewJsonResponderOrPanic(200, linodego.Instance{ID: 1, Label: "web-1", Region: "us-east"}))
+
+	httpmock.RegisterRegexpResponder("GET", mockRequestURL(t, "linode/instances/1/configs"),
+		httpmock.NewJsonResponderOrPanic(200, map[string]any{
+			"data": []linodego.InstanceConfig{
+				{ID: 10, Interfaces: []linodego.InstanceConfigInterface{
+					{ID: 100, Purpose: linodego.InterfacePurposeVLAN, Label: "backend", IPAMAddress: "10.0.0.2/24"},
+				}},
+			},
+			"page":    1,
+			"pages":   1,
+			"results": 1,
+		}))
+
+	entry, err := client.GetVLANInventoryEntry(context.Background(), "us-east", "backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(filter, `"label":"backend"`) || !strings.Contains(filter, `"region":"us-east"`) {
+		t.Errorf("expected the VLANs to be filtered by label and region, got %q", filter)
+	}
+
+	if len(entry.Members) != 1 || entry.Members[0].IPAMAddress != "10.0.0.2/24" || len(entry.Issues) != 0 {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	if _, err := client.GetVLANInventoryEntry(context.Background(), "eu-west", "backend"); err == nil {
+		t.Error("expected an error for a VLAN in another region")
+	}
+}
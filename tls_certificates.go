@@ -0,0 +1,249 @@
+package linodego
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// TLSCertificate is a parsed PEM certificate chain together with its private key,
+// for use with NodeBalancer HTTPS configs and Object Storage buckets.
+type TLSCertificate struct {
+	// CertPEM is the PEM-encoded certificate chain, leaf first
+	CertPEM string
+
+	// KeyPEM is the PEM-encoded private key matching the leaf certificate
+	KeyPEM string
+
+	Leaf  *x509.Certificate
+	Chain []*x509.Certificate
+}
+
+// TLSCertificateDeployOptions configure how a certificate is deployed
+type TLSCertificateDeployOptions struct {
+	// RenewBefore is how long before the certificate expires that deploying it produces a warning
+	RenewBefore time.Duration
+}
+
+// NodeBalancerCertUpdateResult describes the outcome of a certificate update for a single config
+type NodeBalancerCertUpdateResult struct {
+	ConfigID            int
+	Port                int
+	PreviousFingerprint string
+	Updated             bool
+}
+
+// NodeBalancerCertRollout describes the deployment of a certificate to a NodeBalancer
+type NodeBalancerCertRollout struct {
+	// Warning is set when the certificate expires within RenewBefore
+	Warning string
+
+	Configs []NodeBalancerCertUpdateResult
+}
+
+// ObjectStorageBucketCertUploadResult describes the upload of a certificate to a bucket
+type ObjectStorageBucketCertUploadResult struct {
+	// Warning is set when the certificate expires within RenewBefore
+	Warning string
+
+	Cert *ObjectStorageBucketCert
+}
+
+// LoadTLSCertificate parses a PEM certificate chain and private key and verifies
+// that the key matches the leaf certificate.
+func LoadTLSCertificate(certPEM, keyPEM []byte) (*TLSCertificate, error) {
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, fmt.Errorf("certificate and key do not form a valid pair: %w", err)
+	}
+
+	chain := make([]*x509.Certificate, 0)
+
+	for rest := certPEM; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no certificates found in PEM data")
+	}
+
+	return &TLSCertificate{
+		CertPEM: strings.TrimSpace(string(certPEM)) + "\n",
+		KeyPEM:  strings.TrimSpace(string(keyPEM)) + "\n",
+		Leaf:    chain[0],
+		Chain:   chain,
+	}, nil
+}
+
+// LoadTLSCertificateFiles reads a PEM certificate chain and private key from disk
+func LoadTLSCertificateFiles(certFile, keyFile string) (*TLSCertificate, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadTLSCertificate(certPEM, keyPEM)
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the leaf certificate as
+// colon-separated uppercase hex, the format used by NodeBalancerConfig.SSLFingerprint.
+func (c *TLSCertificate) Fingerprint() string {
+	sum := sha256.Sum256(c.Leaf.Raw)
+
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = strings.ToUpper(hex.EncodeToString([]byte{b}))
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// MatchesFingerprint returns true if the given fingerprint is that of the leaf certificate.
+// Case and colon separators are ignored.
+func (c *TLSCertificate) MatchesFingerprint(fingerprint string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), ":", ""))
+	}
+
+	return fingerprint != "" && normalize(fingerprint) == normalize(c.Fingerprint())
+}
+
+// CommonName returns the common name of the leaf certificate
+func (c *TLSCertificate) CommonName() string {
+	return c.Leaf.Subject.CommonName
+}
+
+// ExpiresWithin returns true if the leaf certificate expires within the given duration
+func (c *TLSCertificate) ExpiresWithin(d time.Duration) bool {
+	return time.Now().Add(d).After(c.Leaf.NotAfter)
+}
+
+// Validate returns an error if the leaf certificate is not currently valid.
+// If the certificate expires within renewBefore, a warning is returned instead.
+func (c *TLSCertificate) Validate(renewBefore time.Duration) (warning string, err error) {
+	now := time.Now()
+
+	if now.Before(c.Leaf.NotBefore) {
+		return "", fmt.Errorf("certificate %s is not valid before %s", c.CommonName(), c.Leaf.NotBefore.Format(time.RFC3339))
+	}
+
+	if now.After(c.Leaf.NotAfter) {
+		return "", fmt.Errorf("certificate %s expired at %s", c.CommonName(), c.Leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	if c.ExpiresWithin(renewBefore) {
+		warning = fmt.Sprintf("certificate %s expires at %s", c.CommonName(), c.Leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	return warning, nil
+}
+
+// ObjectStorageBucketCertUploadOptions returns the certificate as options for UploadObjectStorageBucketCert
+func (c *TLSCertificate) ObjectStorageBucketCertUploadOptions() ObjectStorageBucketCertUploadOptions {
+	return ObjectStorageBucketCertUploadOptions{
+		Certificate: c.CertPEM,
+		PrivateKey:  c.KeyPEM,
+	}
+}
+
+// UpdateNodeBalancerCertificates deploys the certificate to every HTTPS config of the
+// NodeBalancer with the provided ID. Configs already serving a certificate with the same
+// fingerprint are left untouched. The certificate is validated before any config is updated,
+// and a warning is returned when it expires within opts.RenewBefore.
+func (c *Client) UpdateNodeBalancerCertificates(
+	ctx context.Context,
+	nodebalancerID int,
+	cert *TLSCertificate,
+	opts TLSCertificateDeployOptions,
+) (*NodeBalancerCertRollout, error) {
+	warning, err := cert.Validate(opts.RenewBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := c.ListNodeBalancerConfigs(ctx, nodebalancerID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout := &NodeBalancerCertRollout{
+		Warning: warning,
+		Configs: make([]NodeBalancerCertUpdateResult, 0),
+	}
+
+	for _, cfg := range configs {
+		if cfg.Protocol != ProtocolHTTPS {
+			continue
+		}
+
+		result := NodeBalancerCertUpdateResult{
+			ConfigID:            cfg.ID,
+			Port:                cfg.Port,
+			PreviousFingerprint: cfg.SSLFingerprint,
+		}
+
+		if !cert.MatchesFingerprint(cfg.SSLFingerprint) {
+			opts := cfg.GetUpdateOptions()
+			opts.SSLCert = cert.CertPEM
+			opts.SSLKey = cert.KeyPEM
+
+			if _, err := c.UpdateNodeBalancerConfig(ctx, nodebalancerID, cfg.ID, opts); err != nil {
+				return rollout, fmt.Errorf("failed to update certificate of config %d: %w", cfg.ID, err)
+			}
+
+			result.Updated = true
+		}
+
+		rollout.Configs = append(rollout.Configs, result)
+	}
+
+	return rollout, nil
+}
+
+// UploadObjectStorageBucketTLSCertificate validates the certificate and uploads it to the given bucket.
+// A warning is returned when the certificate expires within opts.RenewBefore.
+func (c *Client) UploadObjectStorageBucketTLSCertificate(
+	ctx context.Context,
+	clusterOrRegionID, bucket string,
+	cert *TLSCertificate,
+	opts TLSCertificateDeployOptions,
+) (*ObjectStorageBucketCertUploadResult, error) {
+	warning, err := cert.Validate(opts.RenewBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := c.UploadObjectStorageBucketCert(ctx, clusterOrRegionID, bucket, cert.ObjectStorageBucketCertUploadOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ObjectStorageBucketCertUploadResult{Warning: warning, Cert: uploaded}, nil
+}
@@ -0,0 +1,92 @@
+package linodego
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func generateTestCertificate(t *testing.T, notAfter time.Time) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestLoadTLSCertificate(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t, time.Now().Add(10*24*time.Hour))
+
+	cert, err := LoadTLSCertificate(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cert.CommonName() != "example.com" {
+		t.Errorf("unexpected common name: %s", cert.CommonName())
+	}
+
+	fingerprint := cert.Fingerprint()
+	if len(strings.Split(fingerprint, ":")) != 32 {
+		t.Errorf("unexpected fingerprint format: %s", fingerprint)
+	}
+
+	if !cert.MatchesFingerprint(strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))) {
+		t.Error("expected fingerprint to match regardless of format")
+	}
+
+	warning, err := cert.Validate(30 * 24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if warning == "" {
+		t.Error("expected an expiry warning")
+	}
+
+	_, otherKey := generateTestCertificate(t, time.Now().Add(time.Hour))
+	if _, err := LoadTLSCertificate(certPEM, otherKey); err == nil {
+		t.Error("expected an error for a mismatched key")
+	}
+}
+
+func TestTLSCertificate_Expired(t *testing.T) {
+	certPEM, keyPEM := generateTestCertificate(t, time.Now().Add(-time.Minute))
+
+	cert, err := LoadTLSCertificate(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cert.Validate(0); err == nil {
+		t.Fatal("expected an error for an expired certificate")
+	}
+}
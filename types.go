@@ -57,6 +57,7 @@ const (
 	ClassHighmem   LinodeTypeClass = "highmem"
 	ClassDedicated LinodeTypeClass = "dedicated"
 	ClassGPU       LinodeTypeClass = "gpu"
+	ClassPremium   LinodeTypeClass = "premium"
 )
 
 // ListTypes lists linode types. This endpoint is cached by default.
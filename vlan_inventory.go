@@ -0,0 +1,293 @@
+package linodego
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+)
+
+// VLANInventoryIssueType is the kind of problem found in a VLAN inventory
+type VLANInventoryIssueType string
+
+// VLANInventoryIssueType constants are the problems GetVLANInventory can detect
+const (
+	VLANIssueDuplicateIPAM    VLANInventoryIssueType = "duplicate_ipam"
+	VLANIssueWrongRegion      VLANInventoryIssueType = "wrong_region"
+	VLANIssueMissingInterface VLANInventoryIssueType = "missing_interface"
+)
+
+// VLANMember is a config interface of a Linode attached to a VLAN
+type VLANMember struct {
+	LinodeID    int
+	LinodeLabel string
+	Region      string
+	ConfigID    int
+	ConfigLabel string
+	InterfaceID int
+
+	// IPAMAddress is the address of the interface in CIDR notation; it is empty when
+	// addressing is managed within the Linode.
+	IPAMAddress string
+}
+
+// VLANInventoryIssue is a problem found with the members of a VLAN
+type VLANInventoryIssue struct {
+	Type      VLANInventoryIssueType
+	LinodeIDs []int
+	Address   string
+	Message   string
+}
+
+// VLANInventoryEntry is a VLAN with its resolved members and any problems found
+type VLANInventoryEntry struct {
+	VLAN    VLAN
+	Members []VLANMember
+	Issues  []VLANInventoryIssue
+}
+
+// GetVLANInventory returns every VLAN on the account with the config interface and IPAM
+// address of each member Linode resolved
+func (c *Client) GetVLANInventory(ctx context.Context) ([]VLANInventoryEntry, error) {
+	vlans, err := c.ListVLANs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, configs, err := c.resolveVLANMembers(ctx, vlans)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]VLANInventoryEntry, len(vlans))
+	for i, vlan := range vlans {
+		result[i] = NewVLANInventoryEntry(vlan, instances, configs)
+	}
+
+	return result, nil
+}
+
+// GetVLANInventoryEntry returns the inventory of the VLAN with the provided label in the provided
+// region. Only the members of that VLAN are resolved.
+func (c *Client) GetVLANInventoryEntry(ctx context.Context, region, label string) (*VLANInventoryEntry, error) {
+	f := Filter{}
+	f.AddField(Eq, "label", label)
+	f.AddField(Eq, "region", region)
+
+	filter, err := f.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	vlans, err := c.ListVLANs(ctx, &ListOptions{Filter: string(filter)})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vlan := range vlans {
+		if vlan.Region != region || vlan.Label != label {
+			continue
+		}
+
+		instances, configs, err := c.resolveVLANMembers(ctx, []VLAN{vlan})
+		if err != nil {
+			return nil, err
+		}
+
+		entry := NewVLANInventoryEntry(vlan, instances, configs)
+
+		return &entry, nil
+	}
+
+	return nil, fmt.Errorf("VLAN %s not found in region %s", label, region)
+}
+
+// resolveVLANMembers fetches the instance and configs of every Linode attached to the VLANs,
+// keyed by Linode ID
+func (c *Client) resolveVLANMembers(ctx context.Context, vlans []VLAN) (map[int]Instance, map[int][]InstanceConfig, error) {
+	instances := make(map[int]Instance)
+	configs := make(map[int][]InstanceConfig)
+
+	for _, vlan := range vlans {
+		for _, linodeID := range vlan.Linodes {
+			if _, ok := instances[linodeID]; ok {
+				continue
+			}
+
+			instance, err := c.GetInstance(ctx, linodeID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get Linode %d: %w", linodeID, err)
+			}
+
+			linodeConfigs, err := c.ListInstanceConfigs(ctx, linodeID, nil)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to list configs of Linode %d: %w", linodeID, err)
+			}
+
+			instances[linodeID] = *instance
+			configs[linodeID] = linodeConfigs
+		}
+	}
+
+	return instances, configs, nil
+}
+
+// NewVLANInventoryEntry resolves the members of the VLAN from the provided instances and
+// their configs, both keyed by Linode ID, and detects duplicate IPAM addresses, members in
+// a different region than the VLAN and members without an interface on the VLAN
+func NewVLANInventoryEntry(vlan VLAN, instances map[int]Instance, configs map[int][]InstanceConfig) VLANInventoryEntry {
+	entry := VLANInventoryEntry{VLAN: vlan}
+
+	for _, linodeID := range vlan.Linodes {
+		instance := instances[linodeID]
+
+		if instance.Region != "" && instance.Region != vlan.Region {
+			entry.Issues = append(entry.Issues, VLANInventoryIssue{
+				Type:      VLANIssueWrongRegion,
+				LinodeIDs: []int{linodeID},
+				Message: fmt.Sprintf("Linode %d is in %s but VLAN %s is in %s",
+					linodeID, instance.Region, vlan.Label, vlan.Region),
+			})
+		}
+
+		found := false
+
+		for _, config := range configs[linodeID] {
+			for _, iface := range config.Interfaces {
+				if iface.Purpose != InterfacePurposeVLAN || iface.Label != vlan.Label {
+					continue
+				}
+
+				found = true
+
+				entry.Members = append(entry.Members, VLANMember{
+					LinodeID:    linodeID,
+					LinodeLabel: instance.Label,
+					Region:      instance.Region,
+					ConfigID:    config.ID,
+					ConfigLabel: config.Label,
+					InterfaceID: iface.ID,
+					IPAMAddress: iface.IPAMAddress,
+				})
+			}
+		}
+
+		if !found {
+			entry.Issues = append(entry.Issues, VLANInventoryIssue{
+				Type:      VLANIssueMissingInterface,
+				LinodeIDs: []int{linodeID},
+				Message:   fmt.Sprintf("Linode %d has no config interface on VLAN %s", linodeID, vlan.Label),
+			})
+		}
+	}
+
+	entry.Issues = append(entry.Issues, findDuplicateVLANAddresses(entry.Members)...)
+
+	return entry
+}
+
+// UsedIPAMAddresses returns the IPAM addresses of the VLAN's members
+func (e VLANInventoryEntry) UsedIPAMAddresses() []string {
+	result := make([]string, 0, len(e.Members))
+
+	for _, member := range e.Members {
+		if member.IPAMAddress != "" {
+			result = append(result, member.IPAMAddress)
+		}
+	}
+
+	return result
+}
+
+// NextFreeIPAMAddress returns the lowest address in the CIDR that is not used by a member
+// of the VLAN, in CIDR notation suitable for InstanceConfigInterfaceCreateOptions.IPAMAddress
+func (e VLANInventoryEntry) NextFreeIPAMAddress(cidr string) (string, error) {
+	return NextFreeVLANIPAMAddress(cidr, e.UsedIPAMAddresses())
+}
+
+// NextFreeVLANIPAMAddress returns the lowest address in the CIDR that is not in use, in CIDR
+// notation. The network and broadcast addresses of the CIDR are never returned.
+func NextFreeVLANIPAMAddress(cidr string, used []string) (string, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+
+	if !prefix.Addr().Is4() || prefix.Bits() > 30 {
+		return "", fmt.Errorf("CIDR %q must be an IPv4 CIDR of /30 or larger", cidr)
+	}
+
+	taken := make(map[netip.Addr]bool, len(used))
+
+	for _, address := range used {
+		addr, err := parseIPAMAddr(address)
+		if err != nil {
+			return "", err
+		}
+
+		taken[addr] = true
+	}
+
+	first, last := ipv4PrefixBounds(prefix)
+
+	for i := first + 1; i < last; i++ {
+		if addr := uint32ToIPv4(i); !taken[addr] {
+			return netip.PrefixFrom(addr, prefix.Bits()).String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("no free address in %s", cidr)
+}
+
+// findDuplicateVLANAddresses reports IPAM addresses used by more than one member. Addresses
+// are compared without their prefix length.
+func findDuplicateVLANAddresses(members []VLANMember) []VLANInventoryIssue {
+	byAddr := make(map[netip.Addr][]int)
+
+	var order []netip.Addr
+
+	for _, member := range members {
+		addr, err := parseIPAMAddr(member.IPAMAddress)
+		if err != nil {
+			continue
+		}
+
+		ids, ok := byAddr[addr]
+		if !ok {
+			order = append(order, addr)
+		}
+
+		// Only one config of a Linode boots at a time, so repeats within a Linode are not duplicates
+		if n := len(ids); n == 0 || ids[n-1] != member.LinodeID {
+			byAddr[addr] = append(ids, member.LinodeID)
+		}
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return order[i].Less(order[j])
+	})
+
+	var result []VLANInventoryIssue
+
+	for _, addr := range order {
+		if ids := byAddr[addr]; len(ids) > 1 {
+			result = append(result, VLANInventoryIssue{
+				Type:      VLANIssueDuplicateIPAM,
+				LinodeIDs: ids,
+				Address:   addr.String(),
+				Message:   fmt.Sprintf("IPAM address %s is used by Linodes %v", addr, ids),
+			})
+		}
+	}
+
+	return result
+}
+
+func parseIPAMAddr(address string) (netip.Addr, error) {
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid IPAM address %q: %w", address, err)
+	}
+
+	return prefix.Addr(), nil
+}
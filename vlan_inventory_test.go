@@ -0,0 +1,59 @@
+package linodego
+
+import (
+	"testing"
+)
+
+func TestNewVLANInventoryEntry(t *testing.T) {
+	vlan := VLAN{Label: "backend", Region: "us-east", Linodes: []int{1, 2, 3}}
+
+	instances := map[int]Instance{
+		1: {ID: 1, Label: "app-1", Region: "us-east"},
+		2: {ID: 2, Label: "app-2", Region: "us-west"},
+		3: {ID: 3, Label: "app-3", Region: "us-east"},
+	}
+
+	vlanConfig := func(id int, ipam string) []InstanceConfig {
+		return []InstanceConfig{{
+			ID: id,
+			Interfaces: []InstanceConfigInterface{
+				{Purpose: InterfacePurposePublic},
+				{ID: id * 10, Purpose: InterfacePurposeVLAN, Label: "backend", IPAMAddress: ipam},
+			},
+		}}
+	}
+
+	configs := map[int][]InstanceConfig{
+		1: vlanConfig(1, "10.0.0.1/24"),
+		2: vlanConfig(2, "10.0.0.1/24"),
+		3: {{ID: 3}},
+	}
+
+	entry := NewVLANInventoryEntry(vlan, instances, configs)
+
+	if len(entry.Members) != 2 || entry.Members[1].InterfaceID != 20 {
+		t.Fatalf("unexpected members: %+v", entry.Members)
+	}
+
+	types := make(map[VLANInventoryIssueType]int)
+	for _, issue := range entry.Issues {
+		types[issue.Type]++
+	}
+
+	if types[VLANIssueWrongRegion] != 1 || types[VLANIssueMissingInterface] != 1 || types[VLANIssueDuplicateIPAM] != 1 {
+		t.Errorf("unexpected issues: %+v", entry.Issues)
+	}
+
+	next, err := entry.NextFreeIPAMAddress("10.0.0.0/24")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next != "10.0.0.2/24" {
+		t.Errorf("unexpected next address: %s", next)
+	}
+
+	if _, err := NextFreeVLANIPAMAddress("10.0.0.0/30", []string{"10.0.0.1/30", "10.0.0.2/30"}); err == nil {
+		t.Error("expected an error for an exhausted CIDR")
+	}
+}
@@ -0,0 +1,373 @@
+package linodego
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net/netip"
+	"sort"
+)
+
+// VPC subnet prefix length limits enforced by the Linode API
+const (
+	VPCSubnetMinPrefixLength = 1
+	VPCSubnetMaxPrefixLength = 29
+)
+
+var (
+	// vpcPrivateRanges are the RFC 1918 ranges VPC subnets may be allocated from
+	vpcPrivateRanges = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+	}
+
+	// vpcReservedRanges cannot be used by VPC subnets because they are used for Linode private IPs
+	vpcReservedRanges = []netip.Prefix{
+		netip.MustParsePrefix("192.168.128.0/17"),
+	}
+)
+
+// VPCVLANOverlap describes a VLAN IPAM address that overlaps a VPC subnet
+type VPCVLANOverlap struct {
+	VLANLabel   string
+	LinodeID    int
+	ConfigID    int
+	IPAMAddress string
+	SubnetID    int
+	SubnetLabel string
+	SubnetIPv4  string
+}
+
+func (o VPCVLANOverlap) String() string {
+	return fmt.Sprintf("VLAN %s address %s on Linode %d overlaps subnet %s (%s)",
+		o.VLANLabel, o.IPAMAddress, o.LinodeID, o.SubnetLabel, o.SubnetIPv4)
+}
+
+// PlanVPCSubnetCIDRs proposes non-overlapping IPv4 CIDRs for new subnets with the provided
+// prefix lengths. Proposals avoid the existing subnet CIDRs, each other and ranges reserved
+// by Linode. When within is empty, CIDRs are allocated from the RFC 1918 private ranges.
+func PlanVPCSubnetCIDRs(existing []string, within string, prefixLengths ...int) ([]string, error) {
+	pools := vpcPrivateRanges
+
+	if within != "" {
+		pool, err := parseVPCSubnetPrefix(within)
+		if err != nil {
+			return nil, err
+		}
+
+		pools = []netip.Prefix{pool}
+	}
+
+	taken := append([]netip.Prefix{}, vpcReservedRanges...)
+
+	for _, cidr := range existing {
+		prefix, err := parseVPCSubnetPrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+
+		taken = append(taken, prefix)
+	}
+
+	result := make([]string, 0, len(prefixLengths))
+
+	for _, bits := range prefixLengths {
+		if bits < VPCSubnetMinPrefixLength || bits > VPCSubnetMaxPrefixLength {
+			return nil, fmt.Errorf("prefix length /%d is outside of /%d to /%d",
+				bits, VPCSubnetMinPrefixLength, VPCSubnetMaxPrefixLength)
+		}
+
+		prefix, ok := firstFreeIPv4Prefix(pools, taken, bits)
+		if !ok {
+			return nil, fmt.Errorf("no free /%d is available", bits)
+		}
+
+		taken = append(taken, prefix)
+		result = append(result, prefix.String())
+	}
+
+	return result, nil
+}
+
+// FreeVPCSubnetAddresses returns up to count unused addresses in the subnet with the provided
+// CIDR, in ascending order. The first two addresses and the last address of a subnet are
+// reserved by Linode and are never returned.
+func FreeVPCSubnetAddresses(subnetIPv4 string, used []string, count int) ([]netip.Addr, error) {
+	subnet, err := parseVPCSubnetPrefix(subnetIPv4)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ranges routed to a Linode occupy every address they contain
+	taken := make([]netip.Prefix, len(used))
+
+	for i, address := range used {
+		if taken[i], err = ParseFirewallAddress(address); err != nil {
+			return nil, err
+		}
+	}
+
+	first, last := ipv4PrefixBounds(subnet)
+	result := make([]netip.Addr, 0, count)
+
+	for i := first + 2; i < last && len(result) < count; i++ {
+		addr := uint32ToIPv4(i)
+
+		if _, ok := firstOverlappingPrefix(taken, netip.PrefixFrom(addr, 32)); !ok {
+			result = append(result, addr)
+		}
+	}
+
+	if len(result) < count {
+		return result, fmt.Errorf("subnet %s only has %d free addresses", subnetIPv4, len(result))
+	}
+
+	return result, nil
+}
+
+// FindVPCVLANOverlaps returns the VLAN IPAM addresses in the provided configs that overlap
+// any of the subnets. The configs are keyed by Linode ID.
+func FindVPCVLANOverlaps(subnets []VPCSubnet, configs map[int][]InstanceConfig) ([]VPCVLANOverlap, error) {
+	prefixes := make([]netip.Prefix, len(subnets))
+
+	for i, subnet := range subnets {
+		prefix, err := parseVPCSubnetPrefix(subnet.IPv4)
+		if err != nil {
+			return nil, err
+		}
+
+		prefixes[i] = prefix
+	}
+
+	linodeIDs := make([]int, 0, len(configs))
+	for id := range configs {
+		linodeIDs = append(linodeIDs, id)
+	}
+
+	sort.Ints(linodeIDs)
+
+	var result []VPCVLANOverlap
+
+	for _, linodeID := range linodeIDs {
+		for _, config := range configs[linodeID] {
+			for _, iface := range config.Interfaces {
+				if iface.Purpose != InterfacePurposeVLAN || iface.IPAMAddress == "" {
+					continue
+				}
+
+				ipam, err := netip.ParsePrefix(iface.IPAMAddress)
+				if err != nil {
+					return nil, fmt.Errorf("invalid IPAM address %q on Linode %d: %w", iface.IPAMAddress, linodeID, err)
+				}
+
+				for i, subnet := range subnets {
+					if !prefixes[i].Overlaps(ipam.Masked()) {
+						continue
+					}
+
+					result = append(result, VPCVLANOverlap{
+						VLANLabel:   iface.Label,
+						LinodeID:    linodeID,
+						ConfigID:    config.ID,
+						IPAMAddress: iface.IPAMAddress,
+						SubnetID:    subnet.ID,
+						SubnetLabel: subnet.Label,
+						SubnetIPv4:  subnet.IPv4,
+					})
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// PlanVPCSubnets proposes CIDRs for new subnets in the VPC with the provided ID
+func (c *Client) PlanVPCSubnets(ctx context.Context, vpcID int, within string, prefixLengths ...int) ([]VPCSubnetCreateOptions, error) {
+	vpc, err := c.GetVPC(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make([]string, len(vpc.Subnets))
+	for i, subnet := range vpc.Subnets {
+		existing[i] = subnet.IPv4
+	}
+
+	cidrs, err := PlanVPCSubnetCIDRs(existing, within, prefixLengths...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan subnets for VPC %d: %w", vpcID, err)
+	}
+
+	result := make([]VPCSubnetCreateOptions, len(cidrs))
+	for i, cidr := range cidrs {
+		result[i] = VPCSubnetCreateOptions{IPv4: cidr}
+	}
+
+	return result, nil
+}
+
+// FreeVPCSubnetIPAddresses returns up to count unused addresses in the subnet with the provided ID
+func (c *Client) FreeVPCSubnetIPAddresses(ctx context.Context, vpcID, subnetID, count int) ([]netip.Addr, error) {
+	subnet, err := c.GetVPCSubnet(ctx, vpcID, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := c.ListVPCIPAddresses(ctx, vpcID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var used []string
+
+	for _, ip := range ips {
+		if ip.SubnetID != subnetID {
+			continue
+		}
+
+		if ip.Address != nil {
+			used = append(used, *ip.Address)
+		}
+
+		if ip.AddressRange != nil {
+			used = append(used, *ip.AddressRange)
+		}
+	}
+
+	return FreeVPCSubnetAddresses(subnet.IPv4, used, count)
+}
+
+// NewVPCInterfaceCreateOptions returns options for a VPC config interface in the subnet with
+// the provided ID using the next free address. When nat1To1 is set, a public IPv4 address of
+// the Linode is mapped to the VPC address.
+func (c *Client) NewVPCInterfaceCreateOptions(
+	ctx context.Context,
+	vpcID, subnetID int,
+	nat1To1 bool,
+) (*InstanceConfigInterfaceCreateOptions, error) {
+	free, err := c.FreeVPCSubnetIPAddresses(ctx, vpcID, subnetID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	ipv4 := &VPCIPv4{VPC: free[0].String()}
+	if nat1To1 {
+		ipv4.NAT1To1 = Pointer("any")
+	}
+
+	return &InstanceConfigInterfaceCreateOptions{
+		Purpose:  InterfacePurposeVPC,
+		SubnetID: Pointer(subnetID),
+		IPv4:     ipv4,
+	}, nil
+}
+
+// FindVPCVLANOverlaps returns the VLAN IPAM addresses of Linodes in the VPC's region that
+// overlap a subnet of the VPC with the provided ID
+func (c *Client) FindVPCVLANOverlaps(ctx context.Context, vpcID int) ([]VPCVLANOverlap, error) {
+	vpc, err := c.GetVPC(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	vlans, err := c.ListVLANs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make(map[int][]InstanceConfig)
+
+	for _, vlan := range vlans {
+		if vlan.Region != vpc.Region {
+			continue
+		}
+
+		for _, linodeID := range vlan.Linodes {
+			if _, ok := configs[linodeID]; ok {
+				continue
+			}
+
+			linodeConfigs, err := c.ListInstanceConfigs(ctx, linodeID, nil)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list configs of Linode %d: %w", linodeID, err)
+			}
+
+			configs[linodeID] = linodeConfigs
+		}
+	}
+
+	return FindVPCVLANOverlaps(vpc.Subnets, configs)
+}
+
+func parseVPCSubnetPrefix(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid subnet %q: %w", cidr, err)
+	}
+
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, fmt.Errorf("subnet %q is not an IPv4 CIDR", cidr)
+	}
+
+	return prefix.Masked(), nil
+}
+
+// firstFreeIPv4Prefix returns the lowest prefix of the requested length within the pools
+// that does not overlap any taken prefix
+func firstFreeIPv4Prefix(pools, taken []netip.Prefix, bits int) (netip.Prefix, bool) {
+	size := uint64(1) << (32 - bits)
+
+	for _, pool := range pools {
+		if pool.Bits() > bits {
+			continue
+		}
+
+		first, last := ipv4PrefixBounds(pool)
+
+		for start := uint64(first); start+size-1 <= uint64(last); {
+			candidate := netip.PrefixFrom(uint32ToIPv4(uint32(start)), bits)
+
+			blocker, blocked := firstOverlappingPrefix(taken, candidate)
+			if !blocked {
+				return candidate, true
+			}
+
+			// Skip past the blocking prefix, keeping the candidate aligned
+			_, blockerLast := ipv4PrefixBounds(blocker)
+			next := uint64(blockerLast) + 1
+
+			start = (next + size - 1) / size * size
+		}
+	}
+
+	return netip.Prefix{}, false
+}
+
+func firstOverlappingPrefix(prefixes []netip.Prefix, prefix netip.Prefix) (netip.Prefix, bool) {
+	for _, p := range prefixes {
+		if p.Overlaps(prefix) {
+			return p, true
+		}
+	}
+
+	return netip.Prefix{}, false
+}
+
+// ipv4PrefixBounds returns the first and last addresses of an IPv4 prefix as integers
+func ipv4PrefixBounds(prefix netip.Prefix) (uint32, uint32) {
+	prefix = prefix.Masked()
+	b := prefix.Addr().As4()
+	first := binary.BigEndian.Uint32(b[:])
+
+	return first, first | uint32((uint64(1)<<(32-prefix.Bits()))-1)
+}
+
+func uint32ToIPv4(i uint32) netip.Addr {
+	var b [4]byte
+
+	binary.BigEndian.PutUint32(b[:], i)
+
+	return netip.AddrFrom4(b)
+}
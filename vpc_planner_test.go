@@ -0,0 +1,69 @@
+package linodego
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPlanVPCSubnetCIDRs(t *testing.T) {
+	cidrs, err := PlanVPCSubnetCIDRs([]string{"10.0.0.0/24", "10.0.2.0/23"}, "10.0.0.0/16", 24, 23, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"10.0.1.0/24", "10.0.4.0/23", "10.0.6.0/24"}
+	if !reflect.DeepEqual(cidrs, expected) {
+		t.Fatalf("unexpected cidrs: %v", cidrs)
+	}
+
+	if _, err := PlanVPCSubnetCIDRs(nil, "192.168.0.0/24", 24, 24); err == nil {
+		t.Error("expected an error when the pool is exhausted")
+	}
+
+	// 192.168.128.0/17 is reserved for Linode private IPs
+	cidrs, err = PlanVPCSubnetCIDRs(nil, "192.168.0.0/16", 17, 17)
+	if err == nil || len(cidrs) != 0 {
+		t.Errorf("expected the reserved range to be skipped, got %v", cidrs)
+	}
+}
+
+func TestFreeVPCSubnetAddresses(t *testing.T) {
+	free, err := FreeVPCSubnetAddresses("10.0.0.0/29", []string{"10.0.0.2", "10.0.0.4/31"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if free[0].String() != "10.0.0.3" {
+		t.Errorf("unexpected free address: %s", free[0])
+	}
+
+	if free, err := FreeVPCSubnetAddresses("10.0.0.0/29", []string{"10.0.0.2", "10.0.0.4/31"}, 3); err == nil {
+		t.Errorf("expected an error, got %v", free)
+	}
+}
+
+func TestVPCTopology(t *testing.T) {
+	vpc := VPC{
+		ID: 1, Label: "prod", Region: "us-east",
+		Subnets: []VPCSubnet{{ID: 2, Label: "app", IPv4: "10.0.0.0/24"}},
+	}
+
+	ips := []VPCIP{
+		{Address: Pointer("10.0.0.2"), NAT1To1: Pointer("203.0.113.5"), LinodeID: 3, SubnetID: 2, InterfaceID: 4, Active: true},
+		{AddressRange: Pointer("10.0.0.16/28"), LinodeID: 3, SubnetID: 2, InterfaceID: 4, Active: true},
+	}
+
+	topology := NewVPCTopology(vpc, ips, map[int]string{3: "web-1"})
+
+	ifaces := topology.Subnets[0].Interfaces
+	if len(ifaces) != 1 || ifaces[0].Address != "10.0.0.2" || ifaces[0].NAT1To1 != "203.0.113.5" ||
+		!reflect.DeepEqual(ifaces[0].Ranges, []string{"10.0.0.16/28"}) {
+		t.Fatalf("unexpected interfaces: %+v", ifaces)
+	}
+
+	dot := topology.DOT()
+	if !strings.Contains(dot, `"subnet_2" -- "linode_3" [label="10.0.0.2\n10.0.0.16/28\nNAT 1:1 203.0.113.5"];`) {
+		t.Errorf("unexpected DOT output:\n%s", dot)
+	}
+}
@@ -0,0 +1,207 @@
+package linodego
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VPCTopology describes a VPC, its subnets and the Linode interfaces attached to them
+type VPCTopology struct {
+	ID      int                 `json:"id"`
+	Label   string              `json:"label"`
+	Region  string              `json:"region"`
+	Subnets []VPCTopologySubnet `json:"subnets"`
+}
+
+// VPCTopologySubnet is a subnet of a VPCTopology
+type VPCTopologySubnet struct {
+	ID         int                    `json:"id"`
+	Label      string                 `json:"label"`
+	IPv4       string                 `json:"ipv4"`
+	Interfaces []VPCTopologyInterface `json:"interfaces"`
+}
+
+// VPCTopologyInterface is a Linode config interface attached to a VPCTopologySubnet
+type VPCTopologyInterface struct {
+	LinodeID    int      `json:"linode_id"`
+	LinodeLabel string   `json:"linode_label"`
+	ConfigID    int      `json:"config_id"`
+	InterfaceID int      `json:"interface_id"`
+	Active      bool     `json:"active"`
+	Address     string   `json:"address,omitempty"`
+	Ranges      []string `json:"ranges,omitempty"`
+	NAT1To1     string   `json:"nat_1_1,omitempty"`
+}
+
+// GetVPCTopology returns the topology of the VPC with the provided ID
+func (c *Client) GetVPCTopology(ctx context.Context, vpcID int) (*VPCTopology, error) {
+	vpc, err := c.GetVPC(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := c.ListVPCIPAddresses(ctx, vpcID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[int]string)
+
+	for _, ip := range ips {
+		if _, ok := labels[ip.LinodeID]; ok {
+			continue
+		}
+
+		instance, err := c.GetInstance(ctx, ip.LinodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Linode %d: %w", ip.LinodeID, err)
+		}
+
+		labels[ip.LinodeID] = instance.Label
+	}
+
+	return NewVPCTopology(*vpc, ips, labels), nil
+}
+
+// NewVPCTopology builds the topology of the VPC from its IP addresses. The labels map
+// Linode IDs to Linode labels and may be nil.
+func NewVPCTopology(vpc VPC, ips []VPCIP, labels map[int]string) *VPCTopology {
+	topology := &VPCTopology{
+		ID:      vpc.ID,
+		Label:   vpc.Label,
+		Region:  vpc.Region,
+		Subnets: make([]VPCTopologySubnet, len(vpc.Subnets)),
+	}
+
+	subnetIndex := make(map[int]int, len(vpc.Subnets))
+
+	for i, subnet := range vpc.Subnets {
+		subnetIndex[subnet.ID] = i
+		topology.Subnets[i] = VPCTopologySubnet{
+			ID:         subnet.ID,
+			Label:      subnet.Label,
+			IPv4:       subnet.IPv4,
+			Interfaces: make([]VPCTopologyInterface, 0),
+		}
+	}
+
+	// Each interface can have an address and any number of routed ranges
+	interfaces := make(map[int]*VPCTopologyInterface)
+	subnetOf := make(map[int]int)
+	order := make([]int, 0)
+
+	for _, ip := range ips {
+		iface, ok := interfaces[ip.InterfaceID]
+		if !ok {
+			iface = &VPCTopologyInterface{
+				LinodeID:    ip.LinodeID,
+				LinodeLabel: labels[ip.LinodeID],
+				ConfigID:    ip.ConfigID,
+				InterfaceID: ip.InterfaceID,
+				Active:      ip.Active,
+			}
+			interfaces[ip.InterfaceID] = iface
+			subnetOf[ip.InterfaceID] = ip.SubnetID
+			order = append(order, ip.InterfaceID)
+		}
+
+		if ip.Address != nil {
+			iface.Address = *ip.Address
+		}
+
+		if ip.AddressRange != nil {
+			iface.Ranges = append(iface.Ranges, *ip.AddressRange)
+		}
+
+		if ip.NAT1To1 != nil {
+			iface.NAT1To1 = *ip.NAT1To1
+		}
+
+		if _, ok := subnetIndex[ip.SubnetID]; !ok {
+			subnetIndex[ip.SubnetID] = len(topology.Subnets)
+			topology.Subnets = append(topology.Subnets, VPCTopologySubnet{
+				ID:         ip.SubnetID,
+				Interfaces: make([]VPCTopologyInterface, 0),
+			})
+		}
+	}
+
+	for _, id := range order {
+		i := subnetIndex[subnetOf[id]]
+		topology.Subnets[i].Interfaces = append(topology.Subnets[i].Interfaces, *interfaces[id])
+	}
+
+	for _, subnet := range topology.Subnets {
+		sort.SliceStable(subnet.Interfaces, func(i, j int) bool {
+			return subnet.Interfaces[i].LinodeID < subnet.Interfaces[j].LinodeID
+		})
+	}
+
+	return topology
+}
+
+// JSON returns the topology as indented JSON
+func (t VPCTopology) JSON() ([]byte, error) {
+	return json.MarshalIndent(t, "", "  ")
+}
+
+// DOT returns the topology as an undirected Graphviz graph
+func (t VPCTopology) DOT() string {
+	var b strings.Builder
+
+	vpcNode := fmt.Sprintf("vpc_%d", t.ID)
+
+	fmt.Fprintf(&b, "graph %q {\n", t.Label)
+	fmt.Fprintln(&b, "\tnode [shape=box];")
+	fmt.Fprintf(&b, "\t%q [label=%q, style=bold];\n", vpcNode, fmt.Sprintf("%s\n%s", t.Label, t.Region))
+
+	linodes := make(map[int]bool)
+
+	for _, subnet := range t.Subnets {
+		subnetNode := fmt.Sprintf("subnet_%d", subnet.ID)
+
+		fmt.Fprintf(&b, "\t%q [label=%q, style=rounded];\n", subnetNode, fmt.Sprintf("%s\n%s", subnet.Label, subnet.IPv4))
+		fmt.Fprintf(&b, "\t%q -- %q;\n", vpcNode, subnetNode)
+
+		for _, iface := range subnet.Interfaces {
+			linodeNode := fmt.Sprintf("linode_%d", iface.LinodeID)
+
+			if !linodes[iface.LinodeID] {
+				linodes[iface.LinodeID] = true
+
+				label := iface.LinodeLabel
+				if label == "" {
+					label = fmt.Sprintf("Linode %d", iface.LinodeID)
+				}
+
+				fmt.Fprintf(&b, "\t%q [label=%q, shape=ellipse];\n", linodeNode, label)
+			}
+
+			edge := make([]string, 0, 2+len(iface.Ranges))
+
+			if iface.Address != "" {
+				edge = append(edge, iface.Address)
+			}
+
+			edge = append(edge, iface.Ranges...)
+
+			if iface.NAT1To1 != "" {
+				edge = append(edge, "NAT 1:1 "+iface.NAT1To1)
+			}
+
+			style := ""
+			if !iface.Active {
+				style = ", style=dashed"
+			}
+
+			fmt.Fprintf(&b, "\t%q -- %q [label=%q%s];\n", subnetNode, linodeNode, strings.Join(edge, "\n"), style)
+		}
+	}
+
+	fmt.Fprintln(&b, "}")
+
+	return b.String()
+}